@@ -1,25 +1,38 @@
 package main
 
 import (
+	"context"
+	"crypto/ed25519"
 	"database/sql"
 	"fmt"
 	"net/http"
+	"time"
 
+	"subscription-service/internal/auth"
 	"subscription-service/internal/config"
+	"subscription-service/internal/events"
 	"subscription-service/internal/handlers"
 	"subscription-service/internal/logger"
+	"subscription-service/internal/metrics"
+	"subscription-service/internal/middleware"
+	"subscription-service/internal/notifier"
 	"subscription-service/internal/repository"
+	"subscription-service/internal/tracing"
 
 	_ "subscription-service/docs"
 
 	"github.com/gin-gonic/gin"
 	_ "github.com/lib/pq"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
 func main() {
+	ctx := context.Background()
+
 	// Load configuration
 	cfg, err := config.LoadConfig()
 	if err != nil {
@@ -30,6 +43,17 @@ func main() {
 	logger.InitLogger(cfg.LogLevel)
 	log := logger.GetLogger()
 
+	// Initialize tracing: a no-op if cfg.OTLPEndpoint is unset.
+	shutdownTracing, err := tracing.Init(ctx, cfg.OTLPEndpoint, cfg.OTLPInsecure)
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+	defer func() {
+		if err := shutdownTracing(ctx); err != nil {
+			log.Errorf("Failed to shut down tracing: %v", err)
+		}
+	}()
+
 	// Connect to database
 	db, err := sql.Open("postgres", cfg.GetDBConnString())
 	if err != nil {
@@ -45,31 +69,149 @@ func main() {
 
 	// Initialize repository and handlers
 	repo := repository.NewPostgresRepository(db)
-	handler := handlers.NewSubscriptionHandler(repo)
+	handler := handlers.NewSubscriptionHandler(metrics.NewInstrumentingRepository(repo))
+
+	// Wire up the notification subsystem: a Postgres-backed store of
+	// registered callbacks and a background dispatcher that delivers
+	// subscription lifecycle events to them.
+	notificationStore := notifier.NewPostgresStore(db)
+	dispatcher := notifier.NewDispatcher(notificationStore, cfg.NotificationMaxAttempts)
+	go dispatcher.Run()
+	repo.SetNotifier(dispatcher)
+	notificationHandler := notifier.NewHandler(notificationStore)
+
+	// Wire up the CloudEvents change stream: a fanout that pushes to live
+	// SSE connections and, for persisted subscribers, retries with backoff.
+	eventStore := events.NewPostgresStore(db)
+	fanout := events.NewFanout(eventStore, cfg.EventsMaxAttempts)
+	go fanout.Run()
+	repo.SetPublisher(fanout)
+	subscriberHandler := events.NewSubscriberHandler(eventStore)
+
+	// Periodically sweep for subscriptions whose end_date has simply
+	// elapsed with nobody touching the record, so subscription.expired
+	// fires even when no PATCH request happens to cross the boundary.
+	go repo.RunExpirySweep(ctx, time.Duration(cfg.ExpirySweepIntervalSeconds)*time.Second)
+
+	// Load the ticket signing/verification keys and wire up the access
+	// ticket issuer and verification middleware.
+	ticketPriv, err := auth.LoadPrivateKey(cfg.TicketPrivateKeyPath)
+	if err != nil {
+		log.Fatalf("Failed to load ticket private key: %v", err)
+	}
+
+	var ticketKeys *auth.KeySet
+	if cfg.TicketKeysetDir != "" {
+		ticketKeys, err = auth.LoadKeySet(cfg.TicketKeysetDir)
+	} else {
+		var pub ed25519.PublicKey
+		pub, err = auth.LoadPublicKey(cfg.TicketPublicKeyPath)
+		if err == nil {
+			ticketKeys = auth.SingleKeySet(pub)
+		}
+	}
+	if err != nil {
+		log.Fatalf("Failed to load ticket verification keys: %v", err)
+	}
+
+	if cfg.TicketMintSecret == "" {
+		log.Fatal("TICKET_MINT_SECRET must be set: ticket minting must be gated behind a trusted caller")
+	}
+
+	// The key id stamped on minted tickets must match the public key that
+	// verifiers will look it up under, so derive it from the signing key
+	// itself rather than assuming it's whichever id is newest in the
+	// keyset: that assumption breaks if a new pubkey is rotated in ahead
+	// of the private key that corresponds to it.
+	signingKeyID, ok := ticketKeys.IdentifyKeyID(ticketPriv.Public().(ed25519.PublicKey))
+	if !ok {
+		log.Fatal("Ticket signing private key has no matching public key in the verification keyset")
+	}
+
+	issuer := auth.NewIssuer(ticketPriv, signingKeyID)
+
+	metrics.RecordBuildInfo()
 
 	// Setup router
 	router := gin.Default()
 	router.Use(gin.Recovery())
 	router.Use(gin.Logger())
+	router.Use(otelgin.Middleware("subscription-service"))
+	router.Use(middleware.RequestID())
+	router.Use(metrics.Middleware())
 
 	// API routes
 	v1 := router.Group("/api/v1")
 	{
-		// Subscription routes
-		v1.POST("/subscriptions", handler.CreateSubscription)
-		v1.GET("/subscriptions", handler.GetAllSubscriptions)
-		v1.GET("/subscriptions/:id", handler.GetSubscription)
-		v1.PATCH("/subscriptions/:id", handler.UpdateSubscription)
-		v1.DELETE("/subscriptions/:id", handler.DeleteSubscription)
-		v1.GET("/subscriptions/total-cost", handler.GetTotalCost)
+		// Access tickets: minting is restricted to trusted internal callers
+		// that hold the shared service secret, not end users.
+		v1.POST("/tickets", auth.RequireMintSecret(cfg.TicketMintSecret), issuer.Mint)
+
+		// Subscription routes, gated by a valid access ticket
+		subscriptions := v1.Group("/subscriptions")
+		subscriptions.Use(auth.Middleware(ticketKeys))
+		{
+			subscriptions.POST("", handler.CreateSubscription)
+			subscriptions.GET("", handler.GetAllSubscriptions)
+			subscriptions.GET("/:id", handler.GetSubscription)
+			subscriptions.PATCH("/:id", handler.UpdateSubscription)
+			subscriptions.DELETE("/:id", handler.DeleteSubscription)
+			subscriptions.GET("/total-cost", handler.GetTotalCost)
+		}
+		// Registered separately: gin joins group-relative paths with a
+		// slash, which would turn ":query" into a path parameter instead
+		// of the literal custom-method suffix AIP-style APIs use.
+		v1.POST("/subscriptions:query", auth.Middleware(ticketKeys), handler.QuerySubscriptions)
 
 		// Health check
 		v1.GET("/health", handler.HealthCheck)
+
+		// Notification subscription routes, gated the same way subscriptions
+		// are: a valid ticket is required, and results/mutations are scoped
+		// to the ticket's user_id unless it carries admin scope.
+		notifications := v1.Group("/notifications")
+		notifications.Use(auth.Middleware(ticketKeys))
+		{
+			notifications.POST("", notificationHandler.Create)
+			notifications.GET("", notificationHandler.GetAll)
+			notifications.GET("/:id", notificationHandler.Get)
+			notifications.PATCH("/:id", notificationHandler.Update)
+			notifications.DELETE("/:id", notificationHandler.Delete)
+		}
+
+		// CloudEvents change stream, gated and scoped the same way
+		// subscriptions and notifications are: a valid ticket is required,
+		// and the stream/registered subscribers are restricted to the
+		// ticket's user_id unless it carries admin scope.
+		v1.GET("/events", auth.Middleware(ticketKeys), events.StreamHandler(fanout))
+		eventSubscribers := v1.Group("/events/subscribers")
+		eventSubscribers.Use(auth.Middleware(ticketKeys))
+		{
+			eventSubscribers.POST("", subscriberHandler.Create)
+			eventSubscribers.GET("", subscriberHandler.GetAll)
+			eventSubscribers.DELETE("/:id", subscriberHandler.Delete)
+		}
 	}
 
 	// Swagger documentation
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	// Metrics: served from the API router by default, or from its own bind
+	// address when MetricsBindAddr is set so it can live behind a different
+	// network policy than the public API.
+	if cfg.MetricsBindAddr != "" {
+		go func() {
+			mux := http.NewServeMux()
+			mux.Handle("/metrics", promhttp.Handler())
+			log.Infof("Metrics server starting on %s", cfg.MetricsBindAddr)
+			if err := http.ListenAndServe(cfg.MetricsBindAddr, mux); err != nil && err != http.ErrServerClosed {
+				log.Errorf("Metrics server failed: %v", err)
+			}
+		}()
+	} else {
+		router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	}
+
 	// Start server
 	serverAddr := fmt.Sprintf("%s:%d", cfg.ServerHost, cfg.ServerPort)
 	log.Infof("Server starting on %s", serverAddr)
@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"net/http"
+	"time"
+
+	"subscription-service/internal/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const defaultTicketTTL = 1 * time.Hour
+
+// MintTicketRequest is the payload accepted by POST /api/v1/tickets.
+type MintTicketRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+	Admin  bool   `json:"admin"`
+	TTL    int    `json:"ttl_seconds"`
+}
+
+// Issuer mints access tickets signed by the server's private key.
+type Issuer struct {
+	priv  ed25519.PrivateKey
+	keyID byte
+}
+
+func NewIssuer(priv ed25519.PrivateKey, keyID byte) *Issuer {
+	return &Issuer{
+		priv:  priv,
+		keyID: keyID,
+	}
+}
+
+func (i *Issuer) Mint(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context())
+
+	var req MintTicketRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Warn("Invalid request body")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		log.WithField("user_id", req.UserID).Warn("Invalid user ID format")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user_id format"})
+		return
+	}
+
+	ttl := defaultTicketTTL
+	if req.TTL > 0 {
+		ttl = time.Duration(req.TTL) * time.Second
+	}
+
+	scope := ScopeRead
+	if req.Admin {
+		scope |= ScopeAdmin
+	}
+
+	now := time.Now()
+	ticket := Ticket{
+		UserID:    userID,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(ttl),
+		Scope:     scope,
+	}
+
+	token := Mint(ticket, i.keyID, i.priv)
+
+	log.WithField("user_id", userID).Info("Access ticket minted")
+	c.JSON(http.StatusCreated, gin.H{
+		"ticket":     token,
+		"expires_at": ticket.ExpiresAt,
+	})
+}
@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// KeySet holds every ticket-verification public key currently accepted,
+// indexed by the key id embedded in the ticket. Loading a directory of keys
+// (instead of a single file) lets operators rotate the signing key without
+// invalidating tickets minted under the previous one: the old public key
+// stays in the set until its files are removed.
+type KeySet struct {
+	keys map[byte]ed25519.PublicKey
+}
+
+// LoadKeySet reads every `<n>.pub` file in dir, where n is the key id
+// referenced by Ticket.KeyID, and returns the resulting KeySet.
+func LoadKeySet(dir string) (*KeySet, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("auth: read keyset dir: %w", err)
+	}
+
+	keys := make(map[byte]ed25519.PublicKey)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".pub") {
+			continue
+		}
+
+		idStr := strings.TrimSuffix(entry.Name(), ".pub")
+		id, err := strconv.Atoi(idStr)
+		if err != nil || id < 0 || id > 255 {
+			continue
+		}
+
+		raw, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("auth: read key %s: %w", entry.Name(), err)
+		}
+		if len(raw) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("auth: key %s is not a valid ed25519 public key", entry.Name())
+		}
+
+		keys[byte(id)] = ed25519.PublicKey(raw)
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("auth: no keys found in %s", dir)
+	}
+
+	return &KeySet{keys: keys}, nil
+}
+
+// LookUp returns the public key for the given key id, or false if it is
+// unknown (e.g. already rotated out).
+func (k *KeySet) LookUp(keyID byte) (ed25519.PublicKey, bool) {
+	pub, ok := k.keys[keyID]
+	return pub, ok
+}
+
+// Latest returns the highest key id currently loaded. The issuer does not
+// use it; its key id comes from IdentifyKeyID.
+func (k *KeySet) Latest() byte {
+	ids := make([]int, 0, len(k.keys))
+	for id := range k.keys {
+		ids = append(ids, int(id))
+	}
+	sort.Ints(ids)
+	return byte(ids[len(ids)-1])
+}
+
+// IdentifyKeyID returns the key id under which pub is registered in the set.
+// The issuer uses this to derive the key id it stamps on minted tickets from
+// the signing private key it was actually handed, rather than from Latest(),
+// so a pubkey added ahead of rotating the matching private key can never
+// produce tickets tagged with a key id the private key doesn't correspond to.
+func (k *KeySet) IdentifyKeyID(pub ed25519.PublicKey) (byte, bool) {
+	for id, candidate := range k.keys {
+		if candidate.Equal(pub) {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// SingleKeySet builds a KeySet containing a single public key under key id
+// 0, for deployments that configure TicketPublicKeyPath instead of a
+// rotation-capable keyset directory.
+func SingleKeySet(pub ed25519.PublicKey) *KeySet {
+	return &KeySet{keys: map[byte]ed25519.PublicKey{0: pub}}
+}
+
+// LoadPublicKey reads a raw ed25519 public key from path.
+func LoadPublicKey(path string) (ed25519.PublicKey, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("auth: read public key: %w", err)
+	}
+	if len(raw) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("auth: public key at %s is not a valid ed25519 public key", path)
+	}
+	return ed25519.PublicKey(raw), nil
+}
+
+// LoadPrivateKey reads a raw 32-byte ed25519 seed from path and expands it
+// into a private key.
+func LoadPrivateKey(path string) (ed25519.PrivateKey, error) {
+	seed, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("auth: read private key: %w", err)
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("auth: private key at %s is not a valid ed25519 seed", path)
+	}
+	return ed25519.NewKeyFromSeed(seed), nil
+}
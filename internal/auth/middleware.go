@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"subscription-service/internal/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContextUserIDKey and ContextScopeKey are the gin context keys the
+// middleware injects once a ticket has been verified.
+const (
+	ContextUserIDKey = "auth_user_id"
+	ContextScopeKey  = "auth_scope"
+)
+
+// Middleware verifies `Authorization: Ticket <token>` headers against keys,
+// rejecting the request on any parse failure, bad signature, or expiry, and
+// otherwise injects the resolved user id and scope into the gin context.
+func Middleware(keys *KeySet) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		log := logger.FromContext(c.Request.Context())
+
+		header := c.GetHeader("Authorization")
+		const prefix = "Ticket "
+		if !strings.HasPrefix(header, prefix) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing or invalid Authorization header"})
+			return
+		}
+
+		token := strings.TrimPrefix(header, prefix)
+		ticket, raw, err := Parse(token)
+		if err != nil {
+			log.WithError(err).Warn("Failed to parse access ticket")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid ticket"})
+			return
+		}
+
+		pub, ok := keys.LookUp(ticket.KeyID)
+		if !ok {
+			log.WithField("key_id", ticket.KeyID).Warn("Access ticket references unknown key id")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid ticket"})
+			return
+		}
+
+		if err := Verify(raw, pub); err != nil {
+			log.WithError(err).Warn("Access ticket failed verification")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid ticket"})
+			return
+		}
+
+		c.Set(ContextUserIDKey, ticket.UserID)
+		c.Set(ContextScopeKey, ticket.Scope)
+		c.Next()
+	}
+}
+
+// RequireMintSecret gates ticket minting behind a pre-shared secret known
+// only to trusted internal callers. Without it, any anonymous caller could
+// hit POST /api/v1/tickets and self-issue a ticket for an arbitrary user_id
+// -- including one with admin scope -- which would defeat the whole point of
+// the access-ticket system. Callers present the secret via the
+// X-Service-Secret header.
+func RequireMintSecret(secret string) gin.HandlerFunc {
+	want := []byte(secret)
+
+	return func(c *gin.Context) {
+		got := []byte(c.GetHeader("X-Service-Secret"))
+		if len(got) != len(want) || subtle.ConstantTimeCompare(got, want) != 1 {
+			logger.FromContext(c.Request.Context()).Warn("Rejected ticket mint request with missing or invalid service secret")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing or invalid service secret"})
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func mustKeyPair(t *testing.T) (ed25519.PublicKey, ed25519.PrivateKey) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("generate key pair: %v", err)
+	}
+	return pub, priv
+}
+
+func TestMintAndVerifyRoundTrip(t *testing.T) {
+	pub, priv := mustKeyPair(t)
+
+	now := time.Now()
+	ticket := Ticket{
+		UserID:    uuid.New(),
+		IssuedAt:  now,
+		ExpiresAt: now.Add(time.Hour),
+		Scope:     ScopeRead,
+	}
+
+	token := Mint(ticket, 0, priv)
+
+	parsed, raw, err := Parse(token)
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if parsed.UserID != ticket.UserID {
+		t.Fatalf("UserID = %v, want %v", parsed.UserID, ticket.UserID)
+	}
+
+	if err := Verify(raw, pub); err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+}
+
+func TestVerifyRejectsTamperedField(t *testing.T) {
+	pub, priv := mustKeyPair(t)
+
+	now := time.Now()
+	ticket := Ticket{
+		UserID:    uuid.New(),
+		IssuedAt:  now,
+		ExpiresAt: now.Add(time.Hour),
+		Scope:     ScopeRead,
+	}
+
+	token := Mint(ticket, 0, priv)
+	_, raw, err := Parse(token)
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	// Flip the scope byte after signing, as if an attacker tried to upgrade
+	// their own read-only ticket to admin without the private key.
+	tampered := append([]byte{}, raw...)
+	tampered[34] ^= byte(ScopeAdmin)
+
+	if err := Verify(tampered, pub); err != ErrInvalidSignature {
+		t.Fatalf("Verify(tampered) = %v, want ErrInvalidSignature", err)
+	}
+}
+
+func TestVerifyRejectsExpiredTicket(t *testing.T) {
+	pub, priv := mustKeyPair(t)
+
+	now := time.Now()
+	ticket := Ticket{
+		UserID:    uuid.New(),
+		IssuedAt:  now.Add(-2 * time.Hour),
+		ExpiresAt: now.Add(-time.Hour),
+		Scope:     ScopeRead,
+	}
+
+	token := Mint(ticket, 0, priv)
+	_, raw, err := Parse(token)
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	if err := Verify(raw, pub); err != ErrTicketExpired {
+		t.Fatalf("Verify(expired) = %v, want ErrTicketExpired", err)
+	}
+}
+
+func TestParseRejectsMalformedToken(t *testing.T) {
+	if _, _, err := Parse("not-valid-base64!!"); err != ErrMalformedTicket {
+		t.Fatalf("Parse(invalid base64) = %v, want ErrMalformedTicket", err)
+	}
+	if _, _, err := Parse(""); err != ErrMalformedTicket {
+		t.Fatalf("Parse(empty) = %v, want ErrMalformedTicket", err)
+	}
+}
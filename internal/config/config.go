@@ -19,6 +19,21 @@ type Config struct {
 	ServerPort int
 	ServerHost string
 	LogLevel   string
+
+	NotificationMaxAttempts int
+	EventsMaxAttempts       int
+
+	ExpirySweepIntervalSeconds int
+
+	TicketPrivateKeyPath string
+	TicketPublicKeyPath  string
+	TicketKeysetDir      string
+	TicketMintSecret     string
+
+	MetricsBindAddr string
+
+	OTLPEndpoint string
+	OTLPInsecure bool
 }
 
 func LoadConfig() (*Config, error) {
@@ -36,6 +51,21 @@ func LoadConfig() (*Config, error) {
 		ServerPort: getEnvAsInt("SERVER_PORT", 8080),
 		ServerHost: getEnv("SERVER_HOST", "0.0.0.0"),
 		LogLevel:   getEnv("LOG_LEVEL", "info"),
+
+		NotificationMaxAttempts: getEnvAsInt("NOTIFICATION_MAX_ATTEMPTS", 5),
+		EventsMaxAttempts:       getEnvAsInt("EVENTS_MAX_ATTEMPTS", 5),
+
+		ExpirySweepIntervalSeconds: getEnvAsInt("EXPIRY_SWEEP_INTERVAL_SECONDS", 60),
+
+		TicketPrivateKeyPath: getEnv("TICKET_PRIVATE_KEY_PATH", ""),
+		TicketPublicKeyPath:  getEnv("TICKET_PUBLIC_KEY_PATH", ""),
+		TicketKeysetDir:      getEnv("TICKET_KEYSET_DIR", ""),
+		TicketMintSecret:     getEnv("TICKET_MINT_SECRET", ""),
+
+		MetricsBindAddr: getEnv("METRICS_BIND_ADDR", ""),
+
+		OTLPEndpoint: getEnv("OTLP_ENDPOINT", ""),
+		OTLPInsecure: getEnvAsBool("OTLP_INSECURE", true),
 	}
 
 	return config, nil
@@ -60,3 +90,12 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolVal, err := strconv.ParseBool(value); err == nil {
+			return boolVal
+		}
+	}
+	return defaultValue
+}
@@ -0,0 +1,66 @@
+package events
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	models "subscription-service/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// specVersion is the CloudEvents spec version this package emits.
+const specVersion = "1.0"
+
+// ChangeType identifies what happened to a subscription, used to build the
+// CloudEvents `type` attribute (e.g. com.aksim.subscription.created.v1).
+type ChangeType string
+
+const (
+	ChangeCreated ChangeType = "created"
+	ChangeUpdated ChangeType = "updated"
+	ChangeDeleted ChangeType = "deleted"
+)
+
+// CloudEvent is the CloudEvents 1.0 JSON envelope published for every
+// subscription change.
+type CloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject"`
+	Time            string          `json:"time"`
+	DataContentType string          `json:"datacontenttype"`
+	Data            json.RawMessage `json:"data"`
+}
+
+var source = eventSource()
+
+// NewSubscriptionEvent builds the CloudEvent for a subscription change.
+func NewSubscriptionEvent(change ChangeType, sub *models.Subscription) (CloudEvent, error) {
+	data, err := json.Marshal(sub)
+	if err != nil {
+		return CloudEvent{}, err
+	}
+
+	return CloudEvent{
+		SpecVersion:     specVersion,
+		ID:              uuid.New().String(),
+		Source:          source,
+		Type:            "com.aksim.subscription." + string(change) + ".v1",
+		Subject:         sub.ID.String(),
+		Time:            time.Now().UTC().Format(time.RFC3339),
+		DataContentType: "application/json",
+		Data:            data,
+	}, nil
+}
+
+func eventSource() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "subscription-service"
+	}
+	return "/" + host + "/subscription-service"
+}
@@ -0,0 +1,170 @@
+package events
+
+import (
+	"net/http"
+	"time"
+
+	"subscription-service/internal/auth"
+	"subscription-service/internal/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// SubscriberHandler exposes CRUD endpoints for registered HTTP push
+// subscribers under /api/v1/events/subscribers.
+type SubscriberHandler struct {
+	store Store
+}
+
+func NewSubscriberHandler(store Store) *SubscriberHandler {
+	return &SubscriberHandler{
+		store: store,
+	}
+}
+
+func (h *SubscriberHandler) Create(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context())
+
+	var req CreateSubscriberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Warn("Invalid request body")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	qos := req.QoS
+	if qos == "" {
+		qos = QoSFireAndForget
+	}
+
+	sub := &Subscriber{
+		ID:        uuid.New(),
+		URL:       req.URL,
+		Filter:    req.Filter,
+		QoS:       qos,
+		CreatedAt: time.Now(),
+	}
+	scopeResourceFilterToRequester(c, &sub.Filter)
+
+	if err := h.store.CreateSubscriber(c.Request.Context(), sub); err != nil {
+		log.WithError(err).Error("Failed to create event subscriber")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create event subscriber"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, sub)
+}
+
+func (h *SubscriberHandler) GetAll(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context())
+
+	subs, err := h.store.GetSubscribers(c.Request.Context())
+	if err != nil {
+		log.WithError(err).Error("Failed to fetch event subscribers")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch event subscribers"})
+		return
+	}
+
+	c.JSON(http.StatusOK, visibleSubscribersToRequester(c, subs))
+}
+
+func (h *SubscriberHandler) Delete(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context())
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid subscriber id"})
+		return
+	}
+
+	subs, err := h.store.GetSubscribers(c.Request.Context())
+	if err != nil {
+		log.WithError(err).Error("Failed to fetch event subscribers")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete event subscriber"})
+		return
+	}
+	existing := findSubscriber(subs, id)
+	if existing == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "event subscriber not found"})
+		return
+	}
+	if !ownsResourceFilter(c, existing.Filter) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "not allowed to delete this event subscriber"})
+		return
+	}
+
+	if err := h.store.DeleteSubscriber(c.Request.Context(), id); err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "event subscriber not found"})
+			return
+		}
+		log.WithError(err).WithField("id", id).Error("Failed to delete event subscriber")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete event subscriber"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "event subscriber deleted successfully"})
+}
+
+func findSubscriber(subs []Subscriber, id uuid.UUID) *Subscriber {
+	for i := range subs {
+		if subs[i].ID == id {
+			return &subs[i]
+		}
+	}
+	return nil
+}
+
+// scopeResourceFilterToRequester forces filter.UserID to the
+// ticket-authenticated caller on c, unless the ticket carries admin scope,
+// mirroring handlers.scopeToRequester for subscriptions.
+func scopeResourceFilterToRequester(c *gin.Context, filter *ResourceFilter) {
+	scope, ok := c.Get(auth.ContextScopeKey)
+	if ok && scope.(auth.Scope).Has(auth.ScopeAdmin) {
+		return
+	}
+
+	userID, ok := c.Get(auth.ContextUserIDKey)
+	if !ok {
+		return
+	}
+
+	id := userID.(uuid.UUID)
+	filter.UserID = &id
+}
+
+// ownsResourceFilter reports whether the ticket-authenticated caller on c
+// may access a resource scoped by filter: either the ticket carries admin
+// scope, or filter.UserID is set and matches the caller. A filter with no
+// UserID (i.e. covering every user) is admin-only.
+func ownsResourceFilter(c *gin.Context, filter ResourceFilter) bool {
+	scope, ok := c.Get(auth.ContextScopeKey)
+	if ok && scope.(auth.Scope).Has(auth.ScopeAdmin) {
+		return true
+	}
+
+	userID, ok := c.Get(auth.ContextUserIDKey)
+	if !ok || filter.UserID == nil {
+		return false
+	}
+
+	return userID.(uuid.UUID) == *filter.UserID
+}
+
+// visibleSubscribersToRequester filters subs down to those the
+// ticket-authenticated caller on c is allowed to see.
+func visibleSubscribersToRequester(c *gin.Context, subs []Subscriber) []Subscriber {
+	scope, ok := c.Get(auth.ContextScopeKey)
+	if ok && scope.(auth.Scope).Has(auth.ScopeAdmin) {
+		return subs
+	}
+
+	visible := make([]Subscriber, 0, len(subs))
+	for _, sub := range subs {
+		if ownsResourceFilter(c, sub.Filter) {
+			visible = append(visible, sub)
+		}
+	}
+	return visible
+}
@@ -0,0 +1,215 @@
+package events
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"subscription-service/internal/logger"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("subscription-service/internal/events")
+
+// DeliveryStatus tracks the outcome of a persisted (QoSPersisted) delivery
+// attempt.
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	DeliveryFailed    DeliveryStatus = "failed"
+)
+
+// Delivery records one persisted delivery attempt so failed deliveries can
+// be inspected and, later, replayed.
+type Delivery struct {
+	ID           string
+	SubscriberID uuid.UUID
+	EventType    string
+	Status       DeliveryStatus
+	Attempts     int
+	LastError    string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Store persists event subscribers and their delivery history.
+//
+// Expected schema (created out of band, same as the rest of the service):
+//
+//	CREATE TABLE event_subscribers (
+//	    id            UUID PRIMARY KEY,
+//	    url           TEXT NOT NULL,
+//	    user_id       UUID,
+//	    service_name  TEXT,
+//	    qos           TEXT NOT NULL,
+//	    created_at    TIMESTAMPTZ NOT NULL
+//	);
+//
+//	CREATE TABLE event_deliveries (
+//	    id              TEXT PRIMARY KEY,
+//	    subscriber_id   UUID NOT NULL REFERENCES event_subscribers(id) ON DELETE CASCADE,
+//	    event_type      TEXT NOT NULL,
+//	    status          TEXT NOT NULL,
+//	    attempts        INT NOT NULL DEFAULT 0,
+//	    last_error      TEXT,
+//	    created_at      TIMESTAMPTZ NOT NULL,
+//	    updated_at      TIMESTAMPTZ NOT NULL
+//	);
+type Store interface {
+	CreateSubscriber(ctx context.Context, s *Subscriber) error
+	GetSubscribers(ctx context.Context) ([]Subscriber, error)
+	DeleteSubscriber(ctx context.Context, id uuid.UUID) error
+	SaveDelivery(ctx context.Context, d *Delivery) error
+	UpdateDelivery(ctx context.Context, d *Delivery) error
+}
+
+type PostgresStore struct {
+	db  *sql.DB
+	log *logrus.Logger
+}
+
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{
+		db:  db,
+		log: logger.GetLogger(),
+	}
+}
+
+// startSpan starts a span for a store operation and tags it with the SQL
+// statement being executed, so it shows up alongside the db.statement
+// attribute in a trace viewer.
+func startSpan(ctx context.Context, op, statement string) (context.Context, trace.Span) {
+	ctx, span := tracer.Start(ctx, "postgres."+op)
+	span.SetAttributes(attribute.String("db.system", "postgresql"))
+	if statement != "" {
+		span.SetAttributes(attribute.String("db.statement", statement))
+	}
+	return ctx, span
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil && err != sql.ErrNoRows {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func (s *PostgresStore) CreateSubscriber(ctx context.Context, sub *Subscriber) error {
+	query := `
+        INSERT INTO event_subscribers (id, url, user_id, service_name, qos, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6)
+    `
+	ctx, span := startSpan(ctx, "create_subscriber", query)
+	defer span.End()
+
+	_, err := s.db.ExecContext(ctx, query, sub.ID, sub.URL, sub.Filter.UserID, sub.Filter.ServiceName, sub.QoS, sub.CreatedAt)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Error("Failed to create event subscriber")
+		endSpan(span, err)
+		return err
+	}
+
+	endSpan(span, nil)
+	return nil
+}
+
+func (s *PostgresStore) GetSubscribers(ctx context.Context) ([]Subscriber, error) {
+	query := `
+        SELECT id, url, user_id, service_name, qos, created_at
+        FROM event_subscribers
+    `
+	ctx, span := startSpan(ctx, "get_subscribers", query)
+	defer span.End()
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Error("Failed to fetch event subscribers")
+		endSpan(span, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subscribers []Subscriber
+	for rows.Next() {
+		sub := Subscriber{}
+		if err := rows.Scan(&sub.ID, &sub.URL, &sub.Filter.UserID, &sub.Filter.ServiceName, &sub.QoS, &sub.CreatedAt); err != nil {
+			logger.FromContext(ctx).WithError(err).Error("Failed to scan event subscriber")
+			endSpan(span, err)
+			return nil, err
+		}
+		subscribers = append(subscribers, sub)
+	}
+
+	endSpan(span, nil)
+	return subscribers, nil
+}
+
+func (s *PostgresStore) DeleteSubscriber(ctx context.Context, id uuid.UUID) error {
+	query := "DELETE FROM event_subscribers WHERE id = $1"
+	ctx, span := startSpan(ctx, "delete_subscriber", query)
+	defer span.End()
+
+	result, err := s.db.ExecContext(ctx, query, id)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).WithField("id", id).Error("Failed to delete event subscriber")
+		endSpan(span, err)
+		return err
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		endSpan(span, sql.ErrNoRows)
+		return sql.ErrNoRows
+	}
+
+	endSpan(span, nil)
+	return nil
+}
+
+func (s *PostgresStore) SaveDelivery(ctx context.Context, d *Delivery) error {
+	query := `
+        INSERT INTO event_deliveries (id, subscriber_id, event_type, status, attempts, last_error, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+    `
+	ctx, span := startSpan(ctx, "save_delivery", query)
+	defer span.End()
+
+	_, err := s.db.ExecContext(ctx, query, d.ID, d.SubscriberID, d.EventType, d.Status, d.Attempts, d.LastError, d.CreatedAt, d.UpdatedAt)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Error("Failed to save event delivery")
+		endSpan(span, err)
+		return err
+	}
+
+	endSpan(span, nil)
+	return nil
+}
+
+func (s *PostgresStore) UpdateDelivery(ctx context.Context, d *Delivery) error {
+	query := `
+        UPDATE event_deliveries
+        SET status = $1, attempts = $2, last_error = $3, updated_at = $4
+        WHERE id = $5
+    `
+	ctx, span := startSpan(ctx, "update_delivery", query)
+	defer span.End()
+
+	_, err := s.db.ExecContext(ctx, query, d.Status, d.Attempts, d.LastError, d.UpdatedAt, d.ID)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Error("Failed to update event delivery")
+		endSpan(span, err)
+		return err
+	}
+
+	endSpan(span, nil)
+	return nil
+}
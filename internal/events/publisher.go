@@ -0,0 +1,210 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"subscription-service/internal/logger"
+	models "subscription-service/internal/model"
+)
+
+const (
+	pushQueueSize      = 256
+	defaultMaxAttempts = 5
+	initialPushBackoff = 1 * time.Second
+	maxPushBackoff     = 2 * time.Minute
+)
+
+// Publisher fans a CloudEvent out to every interested consumer. It is
+// intentionally narrow so that the in-memory/HTTP implementation here can
+// later be swapped for one backed by NATS or Kafka without touching callers.
+type Publisher interface {
+	Publish(ctx context.Context, ev CloudEvent, sub *models.Subscription)
+}
+
+type pushJob struct {
+	subscriber Subscriber
+	event      CloudEvent
+}
+
+// Fanout is the default Publisher: it delivers events to live SSE
+// connections immediately, and to registered HTTP subscribers either
+// fire-and-forget or with persisted retries, depending on the subscriber's
+// configured QoS.
+type Fanout struct {
+	store       Store
+	httpClient  *http.Client
+	maxAttempts int
+	queue       chan pushJob
+
+	mu  sync.RWMutex
+	sse map[chan CloudEvent]ResourceFilter
+}
+
+func NewFanout(store Store, maxAttempts int) *Fanout {
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxAttempts
+	}
+
+	return &Fanout{
+		store:       store,
+		httpClient:  &http.Client{Timeout: 10 * time.Second},
+		maxAttempts: maxAttempts,
+		queue:       make(chan pushJob, pushQueueSize),
+		sse:         make(map[chan CloudEvent]ResourceFilter),
+	}
+}
+
+// Subscribe registers a live SSE connection and returns the channel it
+// should read events from, plus an unsubscribe function.
+func (f *Fanout) Subscribe(filter ResourceFilter) (chan CloudEvent, func()) {
+	ch := make(chan CloudEvent, 16)
+
+	f.mu.Lock()
+	f.sse[ch] = filter
+	f.mu.Unlock()
+
+	return ch, func() {
+		f.mu.Lock()
+		delete(f.sse, ch)
+		f.mu.Unlock()
+		close(ch)
+	}
+}
+
+func (f *Fanout) Publish(ctx context.Context, ev CloudEvent, sub *models.Subscription) {
+	log := logger.FromContext(ctx)
+
+	f.broadcastSSE(ctx, ev, sub)
+
+	subscribers, err := f.store.GetSubscribers(ctx)
+	if err != nil {
+		log.WithError(err).Error("Failed to load event subscribers")
+		return
+	}
+
+	for _, subscriber := range subscribers {
+		if !subscriber.Filter.matches(sub.UserID, sub.ServiceName) {
+			continue
+		}
+
+		if subscriber.QoS == QoSPersisted {
+			select {
+			case f.queue <- pushJob{subscriber: subscriber, event: ev}:
+			default:
+				log.WithField("subscriber_id", subscriber.ID).Warn("Event push queue full, dropping persisted delivery")
+			}
+			continue
+		}
+
+		go func(url string) {
+			if err := f.post(url, ev); err != nil {
+				log.WithError(err).WithField("url", url).Warn("Fire-and-forget event delivery failed")
+			}
+		}(subscriber.URL)
+	}
+}
+
+func (f *Fanout) broadcastSSE(ctx context.Context, ev CloudEvent, sub *models.Subscription) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	for ch, filter := range f.sse {
+		if !filter.matches(sub.UserID, sub.ServiceName) {
+			continue
+		}
+		select {
+		case ch <- ev:
+		default:
+			logger.FromContext(ctx).Warn("SSE subscriber channel full, dropping event")
+		}
+	}
+}
+
+// Run processes persisted (at-least-once) HTTP deliveries until the queue is
+// closed. It is meant to be started once as a background goroutine.
+//
+// Each job is delivered in its own goroutine so that one unresponsive
+// subscriber, retrying with backoff, can't hold up delivery to every other
+// queued job.
+func (f *Fanout) Run() {
+	for job := range f.queue {
+		go f.deliverPersisted(context.Background(), job.subscriber, job.event)
+	}
+}
+
+func (f *Fanout) deliverPersisted(ctx context.Context, subscriber Subscriber, ev CloudEvent) {
+	log := logger.FromContext(ctx)
+
+	delivery := &Delivery{
+		ID:           ev.ID + ":" + subscriber.ID.String(),
+		SubscriberID: subscriber.ID,
+		EventType:    ev.Type,
+		Status:       DeliveryPending,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+	if err := f.store.SaveDelivery(ctx, delivery); err != nil {
+		log.WithError(err).Error("Failed to persist event delivery")
+	}
+
+	backoff := initialPushBackoff
+	for attempt := 1; attempt <= f.maxAttempts; attempt++ {
+		err := f.post(subscriber.URL, ev)
+		delivery.Attempts = attempt
+		delivery.UpdatedAt = time.Now()
+
+		if err == nil {
+			delivery.Status = DeliveryDelivered
+			delivery.LastError = ""
+			_ = f.store.UpdateDelivery(ctx, delivery)
+			return
+		}
+
+		delivery.LastError = err.Error()
+		log.WithError(err).WithField("subscriber_id", subscriber.ID).Warn("Event delivery attempt failed")
+
+		if attempt == f.maxAttempts {
+			break
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+		if backoff > maxPushBackoff {
+			backoff = maxPushBackoff
+		}
+	}
+
+	delivery.Status = DeliveryFailed
+	_ = f.store.UpdateDelivery(ctx, delivery)
+}
+
+func (f *Fanout) post(url string, ev CloudEvent) error {
+	body, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/cloudevents+json")
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("subscriber returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
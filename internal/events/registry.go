@@ -0,0 +1,50 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// QoS mirrors the two delivery guarantees the O-RAN/redhat-cne event routing
+// model distinguishes between: best-effort and persisted-with-retry.
+type QoS string
+
+const (
+	QoSFireAndForget QoS = "fire_and_forget"
+	QoSPersisted     QoS = "persisted"
+)
+
+// ResourceFilter narrows which subscription changes a subscriber receives.
+// A nil field matches everything.
+type ResourceFilter struct {
+	UserID      *uuid.UUID `json:"user_id,omitempty"`
+	ServiceName *string    `json:"service_name,omitempty"`
+}
+
+func (f ResourceFilter) matches(userID uuid.UUID, serviceName string) bool {
+	if f.UserID != nil && *f.UserID != userID {
+		return false
+	}
+	if f.ServiceName != nil && *f.ServiceName != serviceName {
+		return false
+	}
+	return true
+}
+
+// Subscriber is a registered HTTP push endpoint for the CloudEvents stream.
+type Subscriber struct {
+	ID        uuid.UUID      `json:"id"`
+	URL       string         `json:"url"`
+	Filter    ResourceFilter `json:"filter"`
+	QoS       QoS            `json:"qos"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// CreateSubscriberRequest is the payload accepted by
+// POST /api/v1/events/subscribers.
+type CreateSubscriberRequest struct {
+	URL    string         `json:"url" binding:"required,url"`
+	Filter ResourceFilter `json:"filter"`
+	QoS    QoS            `json:"qos"`
+}
@@ -0,0 +1,57 @@
+package events
+
+import (
+	"io"
+	"net/http"
+
+	"subscription-service/internal/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// StreamHandler serves GET /api/v1/events as a long-poll Server-Sent Events
+// stream of CloudEvents, optionally filtered by user_id/service_name. A
+// non-admin caller is always scoped to their own ticket user_id, regardless
+// of what (if anything) they pass as user_id, so they can't eavesdrop on
+// other users' subscription changes.
+func StreamHandler(fanout *Fanout) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		log := logger.FromContext(c.Request.Context())
+
+		filter := ResourceFilter{}
+		if raw := c.Query("user_id"); raw != "" {
+			userID, err := uuid.Parse(raw)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user_id format"})
+				return
+			}
+			filter.UserID = &userID
+		}
+		if serviceName := c.Query("service_name"); serviceName != "" {
+			filter.ServiceName = &serviceName
+		}
+		scopeResourceFilterToRequester(c, &filter)
+
+		ch, unsubscribe := fanout.Subscribe(filter)
+		defer unsubscribe()
+
+		c.Header("Content-Type", "text/event-stream")
+		c.Header("Cache-Control", "no-cache")
+		c.Header("Connection", "keep-alive")
+
+		c.Stream(func(w io.Writer) bool {
+			select {
+			case ev, ok := <-ch:
+				if !ok {
+					return false
+				}
+				c.SSEvent("message", ev)
+				return true
+			case <-c.Request.Context().Done():
+				log.Debug("Event stream client disconnected")
+				return false
+			}
+		})
+	}
+}
@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
 	"time"
 
+	"subscription-service/internal/auth"
 	"subscription-service/internal/logger"
 	models "subscription-service/internal/model"
 	"subscription-service/internal/repository"
@@ -15,33 +17,33 @@ import (
 
 type SubscriptionHandler struct {
 	repo repository.Repository
-	log  *logrus.Logger
 }
 
 func NewSubscriptionHandler(repo repository.Repository) *SubscriptionHandler {
 	return &SubscriptionHandler{
 		repo: repo,
-		log:  logger.GetLogger(),
 	}
 }
 
 func (h *SubscriptionHandler) CreateSubscription(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context())
+
 	var req models.CreateSubscriptionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		h.log.WithError(err).Warn("Invalid request body")
+		log.WithError(err).Warn("Invalid request body")
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
 	if _, err := time.Parse("01-2006", req.StartDate); err != nil {
-		h.log.WithField("start_date", req.StartDate).Warn("Invalid start date format")
+		log.WithField("start_date", req.StartDate).Warn("Invalid start date format")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid start date format, expected MM-YYYY"})
 		return
 	}
 
 	if req.EndDate != nil {
 		if _, err := time.Parse("01-2006", *req.EndDate); err != nil {
-			h.log.WithField("end_date", *req.EndDate).Warn("Invalid end date format")
+			log.WithField("end_date", *req.EndDate).Warn("Invalid end date format")
 			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid end date format, expected MM-YYYY"})
 			return
 		}
@@ -49,7 +51,7 @@ func (h *SubscriptionHandler) CreateSubscription(c *gin.Context) {
 
 	userID, err := uuid.Parse(req.UserID)
 	if err != nil {
-		h.log.WithField("user_id", req.UserID).Warn("Invalid user ID format")
+		log.WithField("user_id", req.UserID).Warn("Invalid user ID format")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user_id format"})
 		return
 	}
@@ -61,14 +63,15 @@ func (h *SubscriptionHandler) CreateSubscription(c *gin.Context) {
 		StartDate:   req.StartDate,
 		EndDate:     req.EndDate,
 	}
+	scopeSubscriptionToRequester(c, subscription)
 
-	if err := h.repo.Create(subscription); err != nil {
-		h.log.WithError(err).Error("Failed to create subscription")
+	if err := h.repo.Create(c.Request.Context(), subscription); err != nil {
+		log.WithError(err).Error("Failed to create subscription")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create subscription"})
 		return
 	}
 
-	h.log.WithField("id", subscription.ID).Info("Subscription created successfully")
+	log.WithField("id", subscription.ID).Info("Subscription created successfully")
 	c.JSON(http.StatusCreated, gin.H{
 		"id":         subscription.ID,
 		"created_at": subscription.CreatedAt,
@@ -76,127 +79,216 @@ func (h *SubscriptionHandler) CreateSubscription(c *gin.Context) {
 }
 
 func (h *SubscriptionHandler) GetSubscription(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context())
+
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		h.log.WithField("id", c.Param("id")).Warn("Invalid subscription ID")
+		log.WithField("id", c.Param("id")).Warn("Invalid subscription ID")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid subscription id"})
 		return
 	}
 
-	subscription, err := h.repo.GetByID(id)
+	subscription, err := h.repo.GetByID(c.Request.Context(), id)
 	if err != nil {
-		h.log.WithError(err).WithField("id", id).Error("Failed to get subscription")
+		log.WithError(err).WithField("id", id).Error("Failed to get subscription")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get subscription"})
 		return
 	}
 
 	if subscription == nil {
-		h.log.WithField("id", id).Warn("Subscription not found")
+		log.WithField("id", id).Warn("Subscription not found")
 		c.JSON(http.StatusNotFound, gin.H{"error": "subscription not found"})
 		return
 	}
 
+	if !isOwnerOrAdmin(c, subscription.UserID) {
+		log.WithField("id", id).Warn("Ticket does not own this subscription")
+		c.JSON(http.StatusForbidden, gin.H{"error": "not allowed to access this subscription"})
+		return
+	}
+
 	c.JSON(http.StatusOK, subscription)
 }
 
 func (h *SubscriptionHandler) GetAllSubscriptions(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context())
+
 	filter := &models.SubscriptionFilter{
 		UserID:      getStringPointer(c.Query("user_id")),
 		ServiceName: getStringPointer(c.Query("service_name")),
 		StartDate:   getStringPointer(c.Query("start_date")),
 		EndDate:     getStringPointer(c.Query("end_date")),
+		Limit:       getIntQuery(c, "limit"),
+		Offset:      getIntQuery(c, "offset"),
+		SortBy:      c.Query("sort_by"),
+		SortOrder:   c.Query("sort_order"),
+		Cursor:      getStringPointer(c.Query("cursor")),
 	}
+	scopeToRequester(c, filter)
 
-	subscriptions, err := h.repo.GetAll(filter)
+	resp, err := h.repo.GetAll(c.Request.Context(), filter)
 	if err != nil {
-		h.log.WithError(err).Error("Failed to get subscriptions")
+		if err == repository.ErrCursorSortMismatch {
+			log.WithError(err).Warn("Incompatible cursor and sort parameters")
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		log.WithError(err).Error("Failed to get subscriptions")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get subscriptions"})
 		return
 	}
 
-	c.JSON(http.StatusOK, subscriptions)
+	c.JSON(http.StatusOK, resp)
+}
+
+// QuerySubscriptions answers POST /subscriptions:query, for filters too
+// complex to express as query parameters (price ranges, IN lists, etc).
+func (h *SubscriptionHandler) QuerySubscriptions(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context())
+
+	var req models.SubscriptionQueryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Warn("Invalid request body")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	scopeQueryToRequester(c, &req)
+
+	resp, err := h.repo.Query(c.Request.Context(), &req)
+	if err != nil {
+		if err == repository.ErrCursorSortMismatch {
+			log.WithError(err).Warn("Incompatible cursor and sort parameters")
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		log.WithError(err).Error("Failed to query subscriptions")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to query subscriptions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
 }
 
 func (h *SubscriptionHandler) UpdateSubscription(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context())
+
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		h.log.WithField("id", c.Param("id")).Warn("Invalid subscription ID")
+		log.WithField("id", c.Param("id")).Warn("Invalid subscription ID")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid subscription id"})
 		return
 	}
 
 	var req models.UpdateSubscriptionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		h.log.WithError(err).Warn("Invalid request body")
+		log.WithError(err).Warn("Invalid request body")
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
 	if req.EndDate != nil {
 		if _, err := time.Parse("01-2006", *req.EndDate); err != nil {
-			h.log.WithField("end_date", *req.EndDate).Warn("Invalid end date format")
+			log.WithField("end_date", *req.EndDate).Warn("Invalid end date format")
 			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid end date format, expected MM-YYYY"})
 			return
 		}
 	}
 
-	if err := h.repo.Update(id, &req); err != nil {
+	existing, err := h.repo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		log.WithError(err).WithField("id", id).Error("Failed to get subscription")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update subscription"})
+		return
+	}
+	if existing == nil {
+		log.WithField("id", id).Warn("Subscription not found")
+		c.JSON(http.StatusNotFound, gin.H{"error": "subscription not found"})
+		return
+	}
+	if !isOwnerOrAdmin(c, existing.UserID) {
+		log.WithField("id", id).Warn("Ticket does not own this subscription")
+		c.JSON(http.StatusForbidden, gin.H{"error": "not allowed to update this subscription"})
+		return
+	}
+
+	if err := h.repo.Update(c.Request.Context(), id, &req); err != nil {
 		if err.Error() == "sql: no rows in result set" {
-			h.log.WithField("id", id).Warn("Subscription not found")
+			log.WithField("id", id).Warn("Subscription not found")
 			c.JSON(http.StatusNotFound, gin.H{"error": "subscription not found"})
 			return
 		}
-		h.log.WithError(err).WithField("id", id).Error("Failed to update subscription")
+		log.WithError(err).WithField("id", id).Error("Failed to update subscription")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update subscription"})
 		return
 	}
 
-	h.log.WithField("id", id).Info("Subscription updated successfully")
+	log.WithField("id", id).Info("Subscription updated successfully")
 	c.JSON(http.StatusOK, gin.H{"message": "subscription updated successfully"})
 }
 
 func (h *SubscriptionHandler) DeleteSubscription(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context())
+
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		h.log.WithField("id", c.Param("id")).Warn("Invalid subscription ID")
+		log.WithField("id", c.Param("id")).Warn("Invalid subscription ID")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid subscription id"})
 		return
 	}
 
-	if err := h.repo.Delete(id); err != nil {
+	existing, err := h.repo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		log.WithError(err).WithField("id", id).Error("Failed to get subscription")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete subscription"})
+		return
+	}
+	if existing == nil {
+		log.WithField("id", id).Warn("Subscription not found")
+		c.JSON(http.StatusNotFound, gin.H{"error": "subscription not found"})
+		return
+	}
+	if !isOwnerOrAdmin(c, existing.UserID) {
+		log.WithField("id", id).Warn("Ticket does not own this subscription")
+		c.JSON(http.StatusForbidden, gin.H{"error": "not allowed to delete this subscription"})
+		return
+	}
+
+	if err := h.repo.Delete(c.Request.Context(), id); err != nil {
 		if err.Error() == "sql: no rows in result set" {
-			h.log.WithField("id", id).Warn("Subscription not found")
+			log.WithField("id", id).Warn("Subscription not found")
 			c.JSON(http.StatusNotFound, gin.H{"error": "subscription not found"})
 			return
 		}
-		h.log.WithError(err).WithField("id", id).Error("Failed to delete subscription")
+		log.WithError(err).WithField("id", id).Error("Failed to delete subscription")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete subscription"})
 		return
 	}
 
-	h.log.WithField("id", id).Info("Subscription deleted successfully")
+	log.WithField("id", id).Info("Subscription deleted successfully")
 	c.JSON(http.StatusOK, gin.H{"message": "subscription deleted successfully"})
 }
 
 func (h *SubscriptionHandler) GetTotalCost(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context())
+
 	startDate := c.Query("start_date")
 	endDate := c.Query("end_date")
 
 	if startDate == "" || endDate == "" {
-		h.log.Warn("Missing required parameters")
+		log.Warn("Missing required parameters")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "start_date and end_date are required"})
 		return
 	}
 
 	// Validate date formats
 	if _, err := time.Parse("01-2006", startDate); err != nil {
-		h.log.WithField("start_date", startDate).Warn("Invalid start date format")
+		log.WithField("start_date", startDate).Warn("Invalid start date format")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid start_date format, expected MM-YYYY"})
 		return
 	}
 
 	if _, err := time.Parse("01-2006", endDate); err != nil {
-		h.log.WithField("end_date", endDate).Warn("Invalid end date format")
+		log.WithField("end_date", endDate).Warn("Invalid end date format")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid end_date format, expected MM-YYYY"})
 		return
 	}
@@ -205,10 +297,11 @@ func (h *SubscriptionHandler) GetTotalCost(c *gin.Context) {
 		UserID:      getStringPointer(c.Query("user_id")),
 		ServiceName: getStringPointer(c.Query("service_name")),
 	}
+	scopeToRequester(c, filter)
 
-	totalCost, count, err := h.repo.GetTotalCost(filter, startDate, endDate)
+	totalCost, count, err := h.repo.GetTotalCost(c.Request.Context(), filter, startDate, endDate)
 	if err != nil {
-		h.log.WithError(err).Error("Failed to calculate total cost")
+		log.WithError(err).Error("Failed to calculate total cost")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to calculate total cost"})
 		return
 	}
@@ -219,7 +312,7 @@ func (h *SubscriptionHandler) GetTotalCost(c *gin.Context) {
 		Count:     count,
 	}
 
-	h.log.WithFields(logrus.Fields{
+	log.WithFields(logrus.Fields{
 		"total_cost": totalCost,
 		"count":      count,
 	}).Info("Total cost calculated")
@@ -240,3 +333,85 @@ func getStringPointer(s string) *string {
 	}
 	return &s
 }
+
+// isOwnerOrAdmin reports whether the ticket-authenticated caller on c may
+// access a single-resource subscription owned by ownerID: either the ticket
+// carries admin scope, or its user_id matches ownerID.
+func isOwnerOrAdmin(c *gin.Context, ownerID uuid.UUID) bool {
+	scope, ok := c.Get(auth.ContextScopeKey)
+	if ok && scope.(auth.Scope).Has(auth.ScopeAdmin) {
+		return true
+	}
+
+	userID, ok := c.Get(auth.ContextUserIDKey)
+	if !ok {
+		return false
+	}
+
+	return userID.(uuid.UUID) == ownerID
+}
+
+// scopeSubscriptionToRequester forces sub.UserID to the ticket-authenticated
+// caller, unless the ticket carries admin scope, so a non-admin can't create
+// a subscription attributed to an arbitrary user_id.
+func scopeSubscriptionToRequester(c *gin.Context, sub *models.Subscription) {
+	scope, ok := c.Get(auth.ContextScopeKey)
+	if ok && scope.(auth.Scope).Has(auth.ScopeAdmin) {
+		return
+	}
+
+	userID, ok := c.Get(auth.ContextUserIDKey)
+	if !ok {
+		return
+	}
+
+	sub.UserID = userID.(uuid.UUID)
+}
+
+// scopeToRequester forces filter.UserID to the ticket-authenticated caller,
+// unless the ticket carries admin scope, in which case the caller may query
+// across users as before.
+func scopeToRequester(c *gin.Context, filter *models.SubscriptionFilter) {
+	scope, ok := c.Get(auth.ContextScopeKey)
+	if ok && scope.(auth.Scope).Has(auth.ScopeAdmin) {
+		return
+	}
+
+	userID, ok := c.Get(auth.ContextUserIDKey)
+	if !ok {
+		return
+	}
+
+	id := userID.(uuid.UUID).String()
+	filter.UserID = &id
+}
+
+// scopeQueryToRequester is scopeToRequester's equivalent for
+// QuerySubscriptions, restricting the IN-list of user ids to the caller.
+func scopeQueryToRequester(c *gin.Context, req *models.SubscriptionQueryRequest) {
+	scope, ok := c.Get(auth.ContextScopeKey)
+	if ok && scope.(auth.Scope).Has(auth.ScopeAdmin) {
+		return
+	}
+
+	userID, ok := c.Get(auth.ContextUserIDKey)
+	if !ok {
+		return
+	}
+
+	req.UserIDs = []string{userID.(uuid.UUID).String()}
+}
+
+// getIntQuery parses an integer query parameter, returning 0 (meaning
+// "unset") on absence or parse failure.
+func getIntQuery(c *gin.Context, name string) int {
+	raw := c.Query(name)
+	if raw == "" {
+		return 0
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0
+	}
+	return n
+}
@@ -0,0 +1,48 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type contextKey int
+
+const entryContextKey contextKey = 0
+
+// FromContext returns the request-scoped logger stashed in ctx by
+// middleware.RequestID, falling back to the package-level logger (tagged
+// with the trace/span id, if any) when none is present.
+func FromContext(ctx context.Context) *logrus.Entry {
+	if entry, ok := ctx.Value(entryContextKey).(*logrus.Entry); ok {
+		return withSpanFields(ctx, entry)
+	}
+	return withSpanFields(ctx, logrus.NewEntry(GetLogger()))
+}
+
+// NewContext stashes a request-scoped entry (normally built by
+// middleware.RequestID) into ctx.
+func NewContext(ctx context.Context, entry *logrus.Entry) context.Context {
+	return context.WithValue(ctx, entryContextKey, entry)
+}
+
+// NewRequestID generates the value used for the X-Request-ID header when
+// the caller didn't supply one.
+func NewRequestID() string {
+	return uuid.New().String()
+}
+
+// withSpanFields adds trace_id/span_id fields from the active OpenTelemetry
+// span, if any, so every log line can be correlated with a trace.
+func withSpanFields(ctx context.Context, entry *logrus.Entry) *logrus.Entry {
+	spanCtx := trace.SpanContextFromContext(ctx)
+	if !spanCtx.IsValid() {
+		return entry
+	}
+	return entry.WithFields(logrus.Fields{
+		"trace_id": spanCtx.TraceID().String(),
+		"span_id":  spanCtx.SpanID().String(),
+	})
+}
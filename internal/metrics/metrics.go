@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Build-time values, set via -ldflags similarly to how other Go services
+// stamp their build-info gauge.
+var (
+	Version   = "dev"
+	GitCommit = "unknown"
+)
+
+var (
+	HTTPRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "subscription_service_http_requests_total",
+		Help: "Total number of HTTP requests handled, labelled by method, path and status.",
+	}, []string{"method", "path", "status"})
+
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "subscription_service_http_request_duration_seconds",
+		Help:    "HTTP request latency in seconds, labelled by method, path and status.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "path", "status"})
+
+	ActiveSubscriptions = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "subscription_service_active_subscriptions",
+		Help: "Subscriptions created minus subscriptions deleted since this process started, labelled by service_name. Subscriptions that existed before startup are not counted, and a subscription whose end_date has passed is not subtracted.",
+	}, []string{"service_name"})
+
+	TotalCostQueries = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "subscription_service_total_cost_queries_total",
+		Help: "Total number of total-cost query invocations.",
+	})
+
+	DBOperationDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "subscription_service_db_operation_duration_seconds",
+		Help:    "Duration of PostgresRepository operations in seconds, labelled by operation and status.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"operation", "status"})
+
+	BuildInfo = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "subscription_service_build_info",
+		Help: "Build information for the running binary, always 1.",
+	}, []string{"version", "commit"})
+)
+
+// RecordBuildInfo sets the build-info gauge once at startup.
+func RecordBuildInfo() {
+	BuildInfo.WithLabelValues(Version, GitCommit).Set(1)
+}
@@ -0,0 +1,101 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	models "subscription-service/internal/model"
+	"subscription-service/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// InstrumentingRepository decorates a repository.Repository, recording a
+// duration histogram for every call and keeping the active-subscriptions
+// gauge and total-cost query counter up to date.
+type InstrumentingRepository struct {
+	next repository.Repository
+}
+
+func NewInstrumentingRepository(next repository.Repository) *InstrumentingRepository {
+	return &InstrumentingRepository{next: next}
+}
+
+func observe(operation string, start time.Time, err error) {
+	status := "ok"
+	if err != nil {
+		status = "error"
+	}
+	DBOperationDuration.WithLabelValues(operation, status).Observe(time.Since(start).Seconds())
+}
+
+func (r *InstrumentingRepository) Create(ctx context.Context, sub *models.Subscription) error {
+	start := time.Now()
+	err := r.next.Create(ctx, sub)
+	observe("create", start, err)
+	if err == nil {
+		ActiveSubscriptions.WithLabelValues(sub.ServiceName).Inc()
+	}
+	return err
+}
+
+func (r *InstrumentingRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Subscription, error) {
+	start := time.Now()
+	sub, err := r.next.GetByID(ctx, id)
+	observe("get_by_id", start, err)
+	return sub, err
+}
+
+func (r *InstrumentingRepository) GetAll(ctx context.Context, filter *models.SubscriptionFilter) (*models.SubscriptionListResponse, error) {
+	start := time.Now()
+	resp, err := r.next.GetAll(ctx, filter)
+	observe("get_all", start, err)
+	return resp, err
+}
+
+func (r *InstrumentingRepository) Query(ctx context.Context, req *models.SubscriptionQueryRequest) (*models.SubscriptionListResponse, error) {
+	start := time.Now()
+	resp, err := r.next.Query(ctx, req)
+	observe("query", start, err)
+	return resp, err
+}
+
+func (r *InstrumentingRepository) Update(ctx context.Context, id uuid.UUID, req *models.UpdateSubscriptionRequest) error {
+	before, _ := r.next.GetByID(ctx, id)
+
+	start := time.Now()
+	err := r.next.Update(ctx, id, req)
+	observe("update", start, err)
+
+	// ActiveSubscriptions is labelled by service_name, so a rename has to
+	// move the count across labels or the gauge keeps counting the old name
+	// forever.
+	if err == nil && before != nil && req.ServiceName != nil && *req.ServiceName != before.ServiceName {
+		ActiveSubscriptions.WithLabelValues(before.ServiceName).Dec()
+		ActiveSubscriptions.WithLabelValues(*req.ServiceName).Inc()
+	}
+
+	return err
+}
+
+func (r *InstrumentingRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	sub, _ := r.next.GetByID(ctx, id)
+
+	start := time.Now()
+	err := r.next.Delete(ctx, id)
+	observe("delete", start, err)
+
+	if err == nil && sub != nil {
+		ActiveSubscriptions.WithLabelValues(sub.ServiceName).Dec()
+	}
+	return err
+}
+
+func (r *InstrumentingRepository) GetTotalCost(ctx context.Context, filter *models.SubscriptionFilter, startDate, endDate string) (int, int, error) {
+	TotalCostQueries.Inc()
+
+	start := time.Now()
+	totalCost, count, err := r.next.GetTotalCost(ctx, filter, startDate, endDate)
+	observe("get_total_cost", start, err)
+	return totalCost, count, err
+}
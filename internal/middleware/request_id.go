@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"subscription-service/internal/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+const requestIDHeader = "X-Request-ID"
+
+// RequestID generates or propagates an X-Request-ID header and builds the
+// request-scoped *logrus.Entry every handler and repository call should log
+// through. The entry carries only request_id; logger.FromContext adds
+// trace_id/span_id from the active span in the request context when it is
+// called.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(requestIDHeader)
+		if requestID == "" {
+			requestID = logger.NewRequestID()
+		}
+		c.Writer.Header().Set(requestIDHeader, requestID)
+
+		entry := logger.GetLogger().WithFields(logrus.Fields{
+			"request_id": requestID,
+		})
+
+		ctx := logger.NewContext(c.Request.Context(), entry)
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,83 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Subscription is a single paid subscription tracked for a user.
+type Subscription struct {
+	ID          uuid.UUID `json:"id"`
+	ServiceName string    `json:"service_name"`
+	Price       int       `json:"price"`
+	UserID      uuid.UUID `json:"user_id"`
+	StartDate   string    `json:"start_date"`
+	EndDate     *string   `json:"end_date,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// CreateSubscriptionRequest is the payload accepted by POST /subscriptions.
+type CreateSubscriptionRequest struct {
+	ServiceName string  `json:"service_name" binding:"required"`
+	Price       int     `json:"price" binding:"required"`
+	UserID      string  `json:"user_id" binding:"required"`
+	StartDate   string  `json:"start_date" binding:"required"`
+	EndDate     *string `json:"end_date,omitempty"`
+}
+
+// UpdateSubscriptionRequest is the payload accepted by PATCH /subscriptions/:id.
+type UpdateSubscriptionRequest struct {
+	ServiceName *string `json:"service_name,omitempty"`
+	Price       *int    `json:"price,omitempty"`
+	EndDate     *string `json:"end_date,omitempty"`
+}
+
+// SubscriptionFilter narrows GetAll/GetTotalCost to a subset of subscriptions.
+type SubscriptionFilter struct {
+	UserID      *string
+	ServiceName *string
+	StartDate   *string
+	EndDate     *string
+
+	// Pagination. Cursor, when set, takes precedence over Offset for
+	// keyset pagination and encodes the (created_at, id) of the last row
+	// of the previous page.
+	Limit     int
+	Offset    int
+	SortBy    string
+	SortOrder string
+	Cursor    *string
+}
+
+// SubscriptionListResponse is the envelope returned by GetAllSubscriptions.
+type SubscriptionListResponse struct {
+	Data       []*Subscription `json:"data"`
+	NextCursor *string         `json:"next_cursor,omitempty"`
+	Total      int             `json:"total"`
+}
+
+// SubscriptionQueryRequest is the payload accepted by
+// POST /subscriptions:query, for filters too complex to express as query
+// parameters.
+type SubscriptionQueryRequest struct {
+	PriceMin     *int     `json:"price_min,omitempty"`
+	PriceMax     *int     `json:"price_max,omitempty"`
+	ActiveAt     *string  `json:"active_at,omitempty"`
+	ServiceNames []string `json:"service_names,omitempty"`
+	UserIDs      []string `json:"user_ids,omitempty"`
+
+	Limit     int     `json:"limit,omitempty"`
+	Offset    int     `json:"offset,omitempty"`
+	SortBy    string  `json:"sort_by,omitempty"`
+	SortOrder string  `json:"sort_order,omitempty"`
+	Cursor    *string `json:"cursor,omitempty"`
+}
+
+// TotalCostResponse is returned by GET /subscriptions/total-cost.
+type TotalCostResponse struct {
+	TotalCost int    `json:"total_cost"`
+	Currency  string `json:"currency"`
+	Count     int    `json:"count"`
+}
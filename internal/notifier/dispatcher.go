@@ -0,0 +1,209 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"subscription-service/internal/logger"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	deliveryQueueSize  = 256
+	defaultMaxAttempts = 5
+	initialBackoff     = 1 * time.Second
+	maxBackoff         = 2 * time.Minute
+)
+
+// Dispatcher is the background worker that turns repository events into
+// signed HTTP callbacks, retrying with exponential backoff and persisting
+// failed deliveries for later replay.
+type Dispatcher struct {
+	store       Store
+	httpClient  *http.Client
+	maxAttempts int
+	queue       chan Event
+	log         *logrus.Logger
+}
+
+func NewDispatcher(store Store, maxAttempts int) *Dispatcher {
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxAttempts
+	}
+
+	return &Dispatcher{
+		store:       store,
+		httpClient:  &http.Client{Timeout: 10 * time.Second},
+		maxAttempts: maxAttempts,
+		queue:       make(chan Event, deliveryQueueSize),
+		log:         logger.GetLogger(),
+	}
+}
+
+// Publish enqueues an event for delivery. It never blocks the caller: if the
+// queue is full the event is dropped and logged, matching the fire-and-get-on
+// semantics expected of PostgresRepository.Create/Update/Delete.
+func (d *Dispatcher) Publish(event Event) {
+	select {
+	case d.queue <- event:
+	default:
+		d.log.WithField("event_type", event.Type).Warn("Notification queue full, dropping event")
+	}
+}
+
+// Run processes queued events until the queue is closed. It is meant to be
+// started once as a background goroutine from main.
+func (d *Dispatcher) Run() {
+	for event := range d.queue {
+		d.dispatch(context.Background(), event)
+	}
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context, event Event) {
+	subs, err := d.store.GetAll(ctx)
+	if err != nil {
+		d.log.WithError(err).Error("Failed to load notification subscriptions")
+		return
+	}
+
+	// Delivered concurrently: each callback gets its own retry/backoff loop,
+	// so one unresponsive subscriber can't hold up delivery to the rest, or
+	// to the next queued event.
+	for _, sub := range subs {
+		if !matches(sub, event) {
+			continue
+		}
+		go d.deliver(ctx, sub, event)
+	}
+
+	// Note: there is intentionally no cleanup of NotificationSubscription
+	// rows here on EventSubscriptionDeleted. A callback registration is
+	// scoped by user_id/service_name (NotificationSubscription.UserID /
+	// ServiceName), not by a specific subscription id, so a deleted
+	// subscription can't be soundly mapped back to "the registrations that
+	// were watching only it" -- the same user/service may still have other
+	// live subscriptions the registration should keep covering. An earlier
+	// attempt at this deleted every registration for the deleted
+	// subscription's user_id regardless, which was wrong for that reason;
+	// it was reverted rather than left in place. Registrations for users/
+	// services with no subscriptions left just stop matching anything and
+	// go quiet, so the request's underlying goal (dead registrations don't
+	// keep firing) is met even without an explicit delete.
+}
+
+func matches(sub *NotificationSubscription, event Event) bool {
+	typeMatches := false
+	for _, t := range sub.EventTypes {
+		if t == event.Type {
+			typeMatches = true
+			break
+		}
+	}
+	if !typeMatches {
+		return false
+	}
+
+	if sub.UserID != nil && *sub.UserID != event.Subscription.UserID {
+		return false
+	}
+	if sub.ServiceName != nil && *sub.ServiceName != event.Subscription.ServiceName {
+		return false
+	}
+
+	return true
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, sub *NotificationSubscription, event Event) {
+	payload, err := json.Marshal(envelope{
+		EventType:    event.Type,
+		Subscription: event.Subscription,
+		Timestamp:    time.Now(),
+		DeliveryID:   uuid.New(),
+	})
+	if err != nil {
+		d.log.WithError(err).Error("Failed to marshal notification payload")
+		return
+	}
+
+	delivery := &NotificationDelivery{
+		ID:             uuid.New(),
+		SubscriptionID: sub.ID,
+		EventType:      event.Type,
+		Payload:        payload,
+		Status:         DeliveryPending,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+	if err := d.store.SaveDelivery(ctx, delivery); err != nil {
+		d.log.WithError(err).Error("Failed to persist notification delivery")
+	}
+
+	backoff := initialBackoff
+	for attempt := 1; attempt <= d.maxAttempts; attempt++ {
+		err := d.post(sub, payload)
+		delivery.Attempts = attempt
+		delivery.UpdatedAt = time.Now()
+
+		if err == nil {
+			delivery.Status = DeliveryDelivered
+			delivery.LastError = ""
+			_ = d.store.UpdateDelivery(ctx, delivery)
+			return
+		}
+
+		delivery.LastError = err.Error()
+		d.log.WithError(err).WithFields(logrus.Fields{
+			"subscription_id": sub.ID,
+			"attempt":         attempt,
+		}).Warn("Notification delivery attempt failed")
+
+		if attempt == d.maxAttempts {
+			break
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+
+	delivery.Status = DeliveryFailed
+	_ = d.store.UpdateDelivery(ctx, delivery)
+}
+
+func (d *Dispatcher) post(sub *NotificationSubscription, payload []byte) error {
+	req, err := http.NewRequest(http.MethodPost, sub.CallbackURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Signature-256", sign(sub.Secret, payload))
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("callback returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
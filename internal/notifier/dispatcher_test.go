@@ -0,0 +1,81 @@
+package notifier
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	models "subscription-service/internal/model"
+
+	"github.com/google/uuid"
+)
+
+func TestSignMatchesHMACSHA256(t *testing.T) {
+	secret := "top-secret"
+	payload := []byte(`{"event_type":"subscription.created"}`)
+
+	got := sign(secret, payload)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	want := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	if got != want {
+		t.Fatalf("sign() = %q, want %q", got, want)
+	}
+}
+
+func TestSignDiffersByPayloadAndSecret(t *testing.T) {
+	a := sign("secret-a", []byte("payload"))
+	b := sign("secret-b", []byte("payload"))
+	c := sign("secret-a", []byte("other-payload"))
+
+	if a == b {
+		t.Fatal("sign() should differ when the secret changes")
+	}
+	if a == c {
+		t.Fatal("sign() should differ when the payload changes")
+	}
+}
+
+func TestMatchesFiltersByEventTypeUserAndService(t *testing.T) {
+	userA := uuid.New()
+	userB := uuid.New()
+	svcName := "netflix"
+
+	event := Event{
+		Type: EventSubscriptionCreated,
+		Subscription: &models.Subscription{
+			UserID:      userA,
+			ServiceName: svcName,
+		},
+	}
+
+	sub := &NotificationSubscription{
+		EventTypes: []EventType{EventSubscriptionCreated},
+		UserID:     &userA,
+	}
+	if !matches(sub, event) {
+		t.Fatal("expected match on matching event type and user_id")
+	}
+
+	sub.UserID = &userB
+	if matches(sub, event) {
+		t.Fatal("expected no match when user_id filter differs")
+	}
+
+	sub.UserID = nil
+	sub.ServiceName = strPtr("spotify")
+	if matches(sub, event) {
+		t.Fatal("expected no match when service_name filter differs")
+	}
+
+	sub.EventTypes = []EventType{EventSubscriptionDeleted}
+	sub.ServiceName = nil
+	if matches(sub, event) {
+		t.Fatal("expected no match when event type isn't subscribed to")
+	}
+}
+
+func strPtr(s string) *string { return &s }
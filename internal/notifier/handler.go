@@ -0,0 +1,253 @@
+package notifier
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"subscription-service/internal/auth"
+	"subscription-service/internal/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Handler exposes CRUD endpoints for notification subscriptions under
+// /api/v1/notifications.
+type Handler struct {
+	store Store
+}
+
+func NewHandler(store Store) *Handler {
+	return &Handler{
+		store: store,
+	}
+}
+
+func (h *Handler) Create(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context())
+
+	var req CreateNotificationSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Warn("Invalid request body")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sub := &NotificationSubscription{
+		ID:          uuid.New(),
+		CallbackURL: req.CallbackURL,
+		EventTypes:  req.EventTypes,
+		ServiceName: req.ServiceName,
+		Secret:      generateSecret(),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	if req.UserID != nil {
+		userID, err := uuid.Parse(*req.UserID)
+		if err != nil {
+			log.WithField("user_id", *req.UserID).Warn("Invalid user ID format")
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user_id format"})
+			return
+		}
+		sub.UserID = &userID
+	}
+	scopeSubscriptionToRequester(c, sub)
+
+	if err := h.store.Create(c.Request.Context(), sub); err != nil {
+		log.WithError(err).Error("Failed to create notification subscription")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create notification subscription"})
+		return
+	}
+
+	log.WithField("id", sub.ID).Info("Notification subscription created successfully")
+	c.JSON(http.StatusCreated, gin.H{
+		"id":     sub.ID,
+		"secret": sub.Secret,
+	})
+}
+
+func (h *Handler) GetAll(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context())
+
+	subs, err := h.store.GetAll(c.Request.Context())
+	if err != nil {
+		log.WithError(err).Error("Failed to fetch notification subscriptions")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch notification subscriptions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, visibleToRequester(c, subs))
+}
+
+func (h *Handler) Get(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context())
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid notification subscription id"})
+		return
+	}
+
+	sub, err := h.store.GetByID(c.Request.Context(), id)
+	if err != nil {
+		log.WithError(err).WithField("id", id).Error("Failed to get notification subscription")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get notification subscription"})
+		return
+	}
+
+	if sub == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "notification subscription not found"})
+		return
+	}
+
+	if !ownsNotificationSubscription(c, sub) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "not allowed to access this notification subscription"})
+		return
+	}
+
+	c.JSON(http.StatusOK, sub)
+}
+
+func (h *Handler) Update(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context())
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid notification subscription id"})
+		return
+	}
+
+	var req UpdateNotificationSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.WithError(err).Warn("Invalid request body")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	existing, err := h.store.GetByID(c.Request.Context(), id)
+	if err != nil {
+		log.WithError(err).WithField("id", id).Error("Failed to get notification subscription")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update notification subscription"})
+		return
+	}
+	if existing == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "notification subscription not found"})
+		return
+	}
+	if !ownsNotificationSubscription(c, existing) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "not allowed to update this notification subscription"})
+		return
+	}
+
+	if err := h.store.Update(c.Request.Context(), id, &req); err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "notification subscription not found"})
+			return
+		}
+		log.WithError(err).WithField("id", id).Error("Failed to update notification subscription")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update notification subscription"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "notification subscription updated successfully"})
+}
+
+func (h *Handler) Delete(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context())
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid notification subscription id"})
+		return
+	}
+
+	existing, err := h.store.GetByID(c.Request.Context(), id)
+	if err != nil {
+		log.WithError(err).WithField("id", id).Error("Failed to get notification subscription")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete notification subscription"})
+		return
+	}
+	if existing == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "notification subscription not found"})
+		return
+	}
+	if !ownsNotificationSubscription(c, existing) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "not allowed to delete this notification subscription"})
+		return
+	}
+
+	if err := h.store.Delete(c.Request.Context(), id); err != nil {
+		if err.Error() == "sql: no rows in result set" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "notification subscription not found"})
+			return
+		}
+		log.WithError(err).WithField("id", id).Error("Failed to delete notification subscription")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete notification subscription"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "notification subscription deleted successfully"})
+}
+
+// scopeSubscriptionToRequester forces sub.UserID to the ticket-authenticated
+// caller unless the ticket carries admin scope, so a non-admin can't
+// register a callback scoped to (or unscoped, i.e. covering) another user's
+// events.
+func scopeSubscriptionToRequester(c *gin.Context, sub *NotificationSubscription) {
+	scope, ok := c.Get(auth.ContextScopeKey)
+	if ok && scope.(auth.Scope).Has(auth.ScopeAdmin) {
+		return
+	}
+
+	userID, ok := c.Get(auth.ContextUserIDKey)
+	if !ok {
+		return
+	}
+
+	id := userID.(uuid.UUID)
+	sub.UserID = &id
+}
+
+// ownsNotificationSubscription reports whether the ticket-authenticated
+// caller on c may access sub: either the ticket carries admin scope, or
+// sub.UserID is set and matches the caller. A registration with no UserID
+// (i.e. not scoped to a single user) is admin-only.
+func ownsNotificationSubscription(c *gin.Context, sub *NotificationSubscription) bool {
+	scope, ok := c.Get(auth.ContextScopeKey)
+	if ok && scope.(auth.Scope).Has(auth.ScopeAdmin) {
+		return true
+	}
+
+	userID, ok := c.Get(auth.ContextUserIDKey)
+	if !ok || sub.UserID == nil {
+		return false
+	}
+
+	return userID.(uuid.UUID) == *sub.UserID
+}
+
+// visibleToRequester filters subs down to those the ticket-authenticated
+// caller on c is allowed to see (see ownsNotificationSubscription).
+func visibleToRequester(c *gin.Context, subs []*NotificationSubscription) []*NotificationSubscription {
+	scope, ok := c.Get(auth.ContextScopeKey)
+	if ok && scope.(auth.Scope).Has(auth.ScopeAdmin) {
+		return subs
+	}
+
+	visible := make([]*NotificationSubscription, 0, len(subs))
+	for _, sub := range subs {
+		if ownsNotificationSubscription(c, sub) {
+			visible = append(visible, sub)
+		}
+	}
+	return visible
+}
+
+func generateSecret() string {
+	buf := make([]byte, 32)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,95 @@
+package notifier
+
+import (
+	"time"
+
+	models "subscription-service/internal/model"
+
+	"github.com/google/uuid"
+)
+
+// EventType identifies a subscription lifecycle event that can be delivered
+// to a registered callback.
+type EventType string
+
+const (
+	EventSubscriptionCreated EventType = "subscription.created"
+	EventSubscriptionUpdated EventType = "subscription.updated"
+	EventSubscriptionDeleted EventType = "subscription.deleted"
+	EventSubscriptionExpired EventType = "subscription.expired"
+	EventSubscriptionRenewed EventType = "subscription.renewed"
+)
+
+// Event is a single subscription lifecycle occurrence, produced by the
+// repository and consumed by the Dispatcher.
+type Event struct {
+	Type         EventType
+	Subscription *models.Subscription
+}
+
+// Notifier receives lifecycle events and fans them out to registered
+// callback URLs. Implementations must not block the caller.
+type Notifier interface {
+	Publish(event Event)
+}
+
+// NotificationSubscription is a registered callback that wants to receive
+// lifecycle events, optionally filtered by user or service name.
+type NotificationSubscription struct {
+	ID          uuid.UUID   `json:"id"`
+	CallbackURL string      `json:"callback_url"`
+	EventTypes  []EventType `json:"event_types"`
+	UserID      *uuid.UUID  `json:"user_id,omitempty"`
+	ServiceName *string     `json:"service_name,omitempty"`
+	Secret      string      `json:"-"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+// CreateNotificationSubscriptionRequest is the payload accepted by
+// POST /api/v1/notifications.
+type CreateNotificationSubscriptionRequest struct {
+	CallbackURL string      `json:"callback_url" binding:"required,url"`
+	EventTypes  []EventType `json:"event_types" binding:"required,min=1"`
+	UserID      *string     `json:"user_id,omitempty"`
+	ServiceName *string     `json:"service_name,omitempty"`
+}
+
+// UpdateNotificationSubscriptionRequest is the payload accepted by
+// PATCH /api/v1/notifications/:id.
+type UpdateNotificationSubscriptionRequest struct {
+	CallbackURL *string     `json:"callback_url,omitempty" binding:"omitempty,url"`
+	EventTypes  []EventType `json:"event_types,omitempty"`
+}
+
+// DeliveryStatus tracks the outcome of one attempt to deliver an event to a
+// callback URL.
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	DeliveryFailed    DeliveryStatus = "failed"
+)
+
+// NotificationDelivery records one delivery attempt (or the final outcome of
+// a retried delivery) so failed deliveries can be inspected and replayed.
+type NotificationDelivery struct {
+	ID             uuid.UUID      `json:"id"`
+	SubscriptionID uuid.UUID      `json:"subscription_id"`
+	EventType      EventType      `json:"event_type"`
+	Payload        []byte         `json:"payload"`
+	Status         DeliveryStatus `json:"status"`
+	Attempts       int            `json:"attempts"`
+	LastError      string         `json:"last_error,omitempty"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+// envelope is the JSON body POSTed to a callback URL.
+type envelope struct {
+	EventType    EventType   `json:"event_type"`
+	Subscription interface{} `json:"subscription"`
+	Timestamp    time.Time   `json:"timestamp"`
+	DeliveryID   uuid.UUID   `json:"delivery_id"`
+}
@@ -0,0 +1,351 @@
+package notifier
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"subscription-service/internal/logger"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("subscription-service/internal/notifier")
+
+// Store persists notification subscriptions and delivery records.
+//
+// Expected schema (created out of band, same as the rest of the service):
+//
+//	CREATE TABLE notification_subscriptions (
+//	    id            UUID PRIMARY KEY,
+//	    callback_url  TEXT NOT NULL,
+//	    event_types   TEXT[] NOT NULL,
+//	    user_id       UUID,
+//	    service_name  TEXT,
+//	    secret        TEXT NOT NULL,
+//	    created_at    TIMESTAMPTZ NOT NULL,
+//	    updated_at    TIMESTAMPTZ NOT NULL
+//	);
+//
+//	CREATE TABLE notification_deliveries (
+//	    id              UUID PRIMARY KEY,
+//	    subscription_id UUID NOT NULL REFERENCES notification_subscriptions(id) ON DELETE CASCADE,
+//	    event_type      TEXT NOT NULL,
+//	    payload         JSONB NOT NULL,
+//	    status          TEXT NOT NULL,
+//	    attempts        INT NOT NULL DEFAULT 0,
+//	    last_error      TEXT,
+//	    created_at      TIMESTAMPTZ NOT NULL,
+//	    updated_at      TIMESTAMPTZ NOT NULL
+//	);
+type Store interface {
+	Create(ctx context.Context, sub *NotificationSubscription) error
+	GetByID(ctx context.Context, id uuid.UUID) (*NotificationSubscription, error)
+	GetAll(ctx context.Context) ([]*NotificationSubscription, error)
+	Update(ctx context.Context, id uuid.UUID, req *UpdateNotificationSubscriptionRequest) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	SaveDelivery(ctx context.Context, d *NotificationDelivery) error
+	UpdateDelivery(ctx context.Context, d *NotificationDelivery) error
+	FailedDeliveries(ctx context.Context) ([]*NotificationDelivery, error)
+}
+
+type PostgresStore struct {
+	db  *sql.DB
+	log *logrus.Logger
+}
+
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{
+		db:  db,
+		log: logger.GetLogger(),
+	}
+}
+
+// startSpan starts a span for a store operation and tags it with the SQL
+// statement being executed, so it shows up alongside the db.statement
+// attribute in a trace viewer.
+func startSpan(ctx context.Context, op, statement string) (context.Context, trace.Span) {
+	ctx, span := tracer.Start(ctx, "postgres."+op)
+	span.SetAttributes(attribute.String("db.system", "postgresql"))
+	if statement != "" {
+		span.SetAttributes(attribute.String("db.statement", statement))
+	}
+	return ctx, span
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil && err != sql.ErrNoRows {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func (s *PostgresStore) Create(ctx context.Context, sub *NotificationSubscription) error {
+	query := `
+        INSERT INTO notification_subscriptions (id, callback_url, event_types, user_id, service_name, secret, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+    `
+	ctx, span := startSpan(ctx, "create", query)
+	defer span.End()
+
+	_, err := s.db.ExecContext(ctx, query,
+		sub.ID,
+		sub.CallbackURL,
+		pq.Array(sub.EventTypes),
+		sub.UserID,
+		sub.ServiceName,
+		sub.Secret,
+		sub.CreatedAt,
+		sub.UpdatedAt,
+	)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Error("Failed to create notification subscription")
+		endSpan(span, err)
+		return err
+	}
+
+	endSpan(span, nil)
+	return nil
+}
+
+func (s *PostgresStore) GetByID(ctx context.Context, id uuid.UUID) (*NotificationSubscription, error) {
+	query := `
+        SELECT id, callback_url, event_types, user_id, service_name, secret, created_at, updated_at
+        FROM notification_subscriptions
+        WHERE id = $1
+    `
+	ctx, span := startSpan(ctx, "get_by_id", query)
+	defer span.End()
+
+	sub := &NotificationSubscription{}
+	var eventTypes pq.StringArray
+	err := s.db.QueryRowContext(ctx, query, id).Scan(
+		&sub.ID,
+		&sub.CallbackURL,
+		&eventTypes,
+		&sub.UserID,
+		&sub.ServiceName,
+		&sub.Secret,
+		&sub.CreatedAt,
+		&sub.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			endSpan(span, nil)
+			return nil, nil
+		}
+		logger.FromContext(ctx).WithError(err).WithField("id", id).Error("Failed to fetch notification subscription")
+		endSpan(span, err)
+		return nil, err
+	}
+
+	sub.EventTypes = toEventTypes(eventTypes)
+	endSpan(span, nil)
+	return sub, nil
+}
+
+func (s *PostgresStore) GetAll(ctx context.Context) ([]*NotificationSubscription, error) {
+	query := `
+        SELECT id, callback_url, event_types, user_id, service_name, secret, created_at, updated_at
+        FROM notification_subscriptions
+    `
+	ctx, span := startSpan(ctx, "get_all", query)
+	defer span.End()
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Error("Failed to fetch notification subscriptions")
+		endSpan(span, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subs []*NotificationSubscription
+	for rows.Next() {
+		sub := &NotificationSubscription{}
+		var eventTypes pq.StringArray
+		if err := rows.Scan(
+			&sub.ID,
+			&sub.CallbackURL,
+			&eventTypes,
+			&sub.UserID,
+			&sub.ServiceName,
+			&sub.Secret,
+			&sub.CreatedAt,
+			&sub.UpdatedAt,
+		); err != nil {
+			logger.FromContext(ctx).WithError(err).Error("Failed to scan notification subscription")
+			endSpan(span, err)
+			return nil, err
+		}
+		sub.EventTypes = toEventTypes(eventTypes)
+		subs = append(subs, sub)
+	}
+
+	endSpan(span, nil)
+	return subs, nil
+}
+
+func (s *PostgresStore) Update(ctx context.Context, id uuid.UUID, req *UpdateNotificationSubscriptionRequest) error {
+	query := "UPDATE notification_subscriptions SET updated_at = now()"
+	args := []interface{}{}
+
+	if req.CallbackURL != nil {
+		args = append(args, *req.CallbackURL)
+		query += fmt.Sprintf(", callback_url = $%d", len(args))
+	}
+
+	if req.EventTypes != nil {
+		args = append(args, pq.Array(req.EventTypes))
+		query += fmt.Sprintf(", event_types = $%d", len(args))
+	}
+
+	args = append(args, id)
+	query += fmt.Sprintf(" WHERE id = $%d", len(args))
+
+	ctx, span := startSpan(ctx, "update", query)
+	defer span.End()
+
+	result, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).WithField("id", id).Error("Failed to update notification subscription")
+		endSpan(span, err)
+		return err
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		endSpan(span, sql.ErrNoRows)
+		return sql.ErrNoRows
+	}
+
+	endSpan(span, nil)
+	return nil
+}
+
+func (s *PostgresStore) Delete(ctx context.Context, id uuid.UUID) error {
+	query := "DELETE FROM notification_subscriptions WHERE id = $1"
+	ctx, span := startSpan(ctx, "delete", query)
+	defer span.End()
+
+	result, err := s.db.ExecContext(ctx, query, id)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).WithField("id", id).Error("Failed to delete notification subscription")
+		endSpan(span, err)
+		return err
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		endSpan(span, sql.ErrNoRows)
+		return sql.ErrNoRows
+	}
+
+	endSpan(span, nil)
+	return nil
+}
+
+func (s *PostgresStore) SaveDelivery(ctx context.Context, d *NotificationDelivery) error {
+	query := `
+        INSERT INTO notification_deliveries (id, subscription_id, event_type, payload, status, attempts, last_error, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+    `
+	ctx, span := startSpan(ctx, "save_delivery", query)
+	defer span.End()
+
+	_, err := s.db.ExecContext(ctx, query,
+		d.ID,
+		d.SubscriptionID,
+		d.EventType,
+		json.RawMessage(d.Payload),
+		d.Status,
+		d.Attempts,
+		d.LastError,
+		d.CreatedAt,
+		d.UpdatedAt,
+	)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Error("Failed to save notification delivery")
+		endSpan(span, err)
+		return err
+	}
+
+	endSpan(span, nil)
+	return nil
+}
+
+func (s *PostgresStore) UpdateDelivery(ctx context.Context, d *NotificationDelivery) error {
+	query := `
+        UPDATE notification_deliveries
+        SET status = $1, attempts = $2, last_error = $3, updated_at = $4
+        WHERE id = $5
+    `
+	ctx, span := startSpan(ctx, "update_delivery", query)
+	defer span.End()
+
+	_, err := s.db.ExecContext(ctx, query, d.Status, d.Attempts, d.LastError, d.UpdatedAt, d.ID)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Error("Failed to update notification delivery")
+		endSpan(span, err)
+		return err
+	}
+
+	endSpan(span, nil)
+	return nil
+}
+
+func (s *PostgresStore) FailedDeliveries(ctx context.Context) ([]*NotificationDelivery, error) {
+	query := `
+        SELECT id, subscription_id, event_type, payload, status, attempts, last_error, created_at, updated_at
+        FROM notification_deliveries
+        WHERE status = $1
+    `
+	ctx, span := startSpan(ctx, "failed_deliveries", query)
+	defer span.End()
+
+	rows, err := s.db.QueryContext(ctx, query, DeliveryFailed)
+	if err != nil {
+		endSpan(span, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*NotificationDelivery
+	for rows.Next() {
+		d := &NotificationDelivery{}
+		if err := rows.Scan(
+			&d.ID,
+			&d.SubscriptionID,
+			&d.EventType,
+			&d.Payload,
+			&d.Status,
+			&d.Attempts,
+			&d.LastError,
+			&d.CreatedAt,
+			&d.UpdatedAt,
+		); err != nil {
+			endSpan(span, err)
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	endSpan(span, nil)
+	return deliveries, nil
+}
+
+func toEventTypes(raw pq.StringArray) []EventType {
+	out := make([]EventType, len(raw))
+	for i, v := range raw {
+		out[i] = EventType(v)
+	}
+	return out
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestEncodeDecodeCursorRoundTrip(t *testing.T) {
+	createdAt := time.Date(2026, 3, 5, 12, 30, 0, 0, time.UTC)
+	id := uuid.New()
+
+	encoded := encodeCursor(createdAt, id)
+
+	decoded, err := decodeCursor(encoded)
+	if err != nil {
+		t.Fatalf("decodeCursor returned error: %v", err)
+	}
+
+	if !decoded.CreatedAt.Equal(createdAt) {
+		t.Fatalf("CreatedAt = %v, want %v", decoded.CreatedAt, createdAt)
+	}
+	if decoded.ID != id {
+		t.Fatalf("ID = %v, want %v", decoded.ID, id)
+	}
+}
+
+func TestDecodeCursorRejectsMalformedInput(t *testing.T) {
+	cases := []string{
+		"not-base64!!",
+		"", // handled separately by callers checking for an empty cursor
+	}
+	for _, c := range cases {
+		if _, err := decodeCursor(c); err == nil {
+			t.Fatalf("decodeCursor(%q) = nil error, want error", c)
+		}
+	}
+}
+
+func TestDecodeCursorRejectsBadTimestampOrID(t *testing.T) {
+	badTimestamp := encodeRaw("not-a-time|" + uuid.New().String())
+	if _, err := decodeCursor(badTimestamp); err == nil {
+		t.Fatal("decodeCursor with an invalid timestamp should error")
+	}
+
+	badID := encodeRaw(time.Now().UTC().Format(time.RFC3339Nano) + "|not-a-uuid")
+	if _, err := decodeCursor(badID); err == nil {
+		t.Fatal("decodeCursor with an invalid id should error")
+	}
+}
+
+func TestIsDefaultSort(t *testing.T) {
+	cases := []struct {
+		sortBy, sortOrder string
+		want              bool
+	}{
+		{"", "", true},
+		{"created_at", "desc", true},
+		{"created_at", "", true},
+		{"price", "", false},
+		{"", "asc", false},
+		{"service_name", "asc", false},
+	}
+
+	for _, tc := range cases {
+		if got := isDefaultSort(tc.sortBy, tc.sortOrder); got != tc.want {
+			t.Errorf("isDefaultSort(%q, %q) = %v, want %v", tc.sortBy, tc.sortOrder, got, tc.want)
+		}
+	}
+}
+
+func encodeRaw(s string) string {
+	return base64.URLEncoding.EncodeToString([]byte(s))
+}
@@ -1,56 +1,153 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
+	"subscription-service/internal/events"
 	"subscription-service/internal/logger"
 	models "subscription-service/internal/model"
+	"subscription-service/internal/notifier"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+const (
+	defaultListLimit = 50
+	maxListLimit     = 200
+)
+
+var tracer = otel.Tracer("subscription-service/internal/repository")
+
+// ErrCursorSortMismatch is returned when a cursor is supplied alongside a
+// sort_by/sort_order that isn't the default (created_at, descending). The
+// keyset predicate encodes (created_at, id), so pairing it with a different
+// ORDER BY would silently return wrong, duplicate, or missing rows instead.
+var ErrCursorSortMismatch = errors.New("repository: cursor pagination requires the default sort (created_at, descending)")
+
+// isDefaultSort reports whether sortBy/sortOrder resolve to the
+// (created_at DESC) order the keyset cursor predicate assumes.
+func isDefaultSort(sortBy, sortOrder string) bool {
+	return sortColumn(sortBy) == "created_at" && sortDirection(sortOrder) == "DESC"
+}
+
 type Repository interface {
-	Create(sub *models.Subscription) error
-	GetByID(id uuid.UUID) (*models.Subscription, error)
-	GetAll(filter *models.SubscriptionFilter) ([]*models.Subscription, error)
-	Update(id uuid.UUID, req *models.UpdateSubscriptionRequest) error
-	Delete(id uuid.UUID) error
-	GetTotalCost(filter *models.SubscriptionFilter, startDate, endDate string) (int, int, error)
+	Create(ctx context.Context, sub *models.Subscription) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Subscription, error)
+	GetAll(ctx context.Context, filter *models.SubscriptionFilter) (*models.SubscriptionListResponse, error)
+	Update(ctx context.Context, id uuid.UUID, req *models.UpdateSubscriptionRequest) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	GetTotalCost(ctx context.Context, filter *models.SubscriptionFilter, startDate, endDate string) (int, int, error)
+	Query(ctx context.Context, req *models.SubscriptionQueryRequest) (*models.SubscriptionListResponse, error)
 }
 
 type PostgresRepository struct {
-	db  *sql.DB
-	log *logrus.Logger
+	db        *sql.DB
+	log       *logrus.Logger
+	notifier  notifier.Notifier
+	publisher events.Publisher
+
+	sweepMu sync.Mutex
+	sweptAt time.Time
 }
 
 func NewPostgresRepository(db *sql.DB) *PostgresRepository {
 	return &PostgresRepository{
-		db:  db,
-		log: logger.GetLogger(),
+		db:      db,
+		log:     logger.GetLogger(),
+		sweptAt: time.Now(),
+	}
+}
+
+// SetNotifier wires a Notifier into the repository so that lifecycle events
+// are published after a successful Create/Update/Delete. It is optional;
+// a nil notifier (the zero value) disables publishing.
+func (r *PostgresRepository) SetNotifier(n notifier.Notifier) {
+	r.notifier = n
+}
+
+// SetPublisher wires a CloudEvents Publisher into the repository so that
+// every Create/Update/Delete is also published as a change event. It is
+// optional; a nil publisher (the zero value) disables publishing.
+func (r *PostgresRepository) SetPublisher(p events.Publisher) {
+	r.publisher = p
+}
+
+func (r *PostgresRepository) publish(ctx context.Context, eventType notifier.EventType, sub *models.Subscription) {
+	if r.notifier != nil {
+		r.notifier.Publish(notifier.Event{Type: eventType, Subscription: sub})
+	}
+
+	if r.publisher != nil {
+		change, ok := map[notifier.EventType]events.ChangeType{
+			notifier.EventSubscriptionCreated: events.ChangeCreated,
+			notifier.EventSubscriptionUpdated: events.ChangeUpdated,
+			notifier.EventSubscriptionDeleted: events.ChangeDeleted,
+		}[eventType]
+		if !ok {
+			return
+		}
+
+		ev, err := events.NewSubscriptionEvent(change, sub)
+		if err != nil {
+			logger.FromContext(ctx).WithError(err).Error("Failed to build CloudEvent for subscription change")
+			return
+		}
+		r.publisher.Publish(ctx, ev, sub)
+	}
+}
+
+// startSpan starts a span for a repository operation and tags it with the
+// SQL statement being executed, so it shows up alongside the db.statement
+// attribute in a trace viewer.
+func startSpan(ctx context.Context, op, statement string) (context.Context, trace.Span) {
+	ctx, span := tracer.Start(ctx, "postgres."+op)
+	span.SetAttributes(attribute.String("db.system", "postgresql"))
+	if statement != "" {
+		span.SetAttributes(attribute.String("db.statement", statement))
+	}
+	return ctx, span
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil && err != sql.ErrNoRows {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 	}
+	span.End()
 }
 
-func (r *PostgresRepository) Create(sub *models.Subscription) error {
+func (r *PostgresRepository) Create(ctx context.Context, sub *models.Subscription) error {
 	query := `
         INSERT INTO subscriptions (id, service_name, price, user_id, start_date, end_date, created_at, updated_at)
         VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
     `
+	ctx, span := startSpan(ctx, "create", query)
+	defer span.End()
 
 	sub.ID = uuid.New()
 	sub.CreatedAt = time.Now()
 	sub.UpdatedAt = time.Now()
 
-	r.log.WithFields(logrus.Fields{
+	log := logger.FromContext(ctx)
+	log.WithFields(logrus.Fields{
 		"id":           sub.ID,
 		"user_id":      sub.UserID,
 		"service_name": sub.ServiceName,
 	}).Info("Creating new subscription")
 
-	_, err := r.db.Exec(query,
+	_, err := r.db.ExecContext(ctx, query,
 		sub.ID,
 		sub.ServiceName,
 		sub.Price,
@@ -62,24 +159,31 @@ func (r *PostgresRepository) Create(sub *models.Subscription) error {
 	)
 
 	if err != nil {
-		r.log.WithError(err).Error("Failed to create subscription")
+		log.WithError(err).Error("Failed to create subscription")
+		endSpan(span, err)
 		return err
 	}
 
+	r.publish(ctx, notifier.EventSubscriptionCreated, sub)
+	endSpan(span, nil)
+
 	return nil
 }
 
-func (r *PostgresRepository) GetByID(id uuid.UUID) (*models.Subscription, error) {
+func (r *PostgresRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Subscription, error) {
 	query := `
         SELECT id, service_name, price, user_id, start_date, end_date, created_at, updated_at
         FROM subscriptions
         WHERE id = $1
     `
+	ctx, span := startSpan(ctx, "get_by_id", query)
+	defer span.End()
 
-	r.log.WithField("id", id).Info("Fetching subscription by ID")
+	log := logger.FromContext(ctx)
+	log.WithField("id", id).Info("Fetching subscription by ID")
 
 	sub := &models.Subscription{}
-	err := r.db.QueryRow(query, id).Scan(
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&sub.ID,
 		&sub.ServiceName,
 		&sub.Price,
@@ -92,56 +196,123 @@ func (r *PostgresRepository) GetByID(id uuid.UUID) (*models.Subscription, error)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			r.log.WithField("id", id).Warn("Subscription not found")
+			log.WithField("id", id).Warn("Subscription not found")
+			endSpan(span, nil)
 			return nil, nil
 		}
-		r.log.WithError(err).WithField("id", id).Error("Failed to fetch subscription")
+		log.WithError(err).WithField("id", id).Error("Failed to fetch subscription")
+		endSpan(span, err)
 		return nil, err
 	}
 
+	endSpan(span, nil)
 	return sub, nil
 }
 
-func (r *PostgresRepository) GetAll(filter *models.SubscriptionFilter) ([]*models.Subscription, error) {
-	query := `
-        SELECT id, service_name, price, user_id, start_date, end_date, created_at, updated_at
-        FROM subscriptions
-        WHERE 1=1
-    `
-	args := []interface{}{}
+// GetAll supports both offset pagination (limit/offset) and stable keyset
+// pagination (cursor), the latter relying on the following index to avoid a
+// sort on every page:
+//
+//	CREATE INDEX idx_subscriptions_created_at_id ON subscriptions (created_at DESC, id DESC);
+func (r *PostgresRepository) GetAll(ctx context.Context, filter *models.SubscriptionFilter) (*models.SubscriptionListResponse, error) {
+	ctx, span := startSpan(ctx, "get_all", "")
+	defer span.End()
+
+	log := logger.FromContext(ctx)
+
+	filterArgs := []interface{}{}
 	conditions := []string{}
 
 	if filter.UserID != nil && *filter.UserID != "" {
-		args = append(args, *filter.UserID)
-		conditions = append(conditions, fmt.Sprintf("user_id = $%d", len(args)))
+		filterArgs = append(filterArgs, *filter.UserID)
+		conditions = append(conditions, fmt.Sprintf("user_id = $%d", len(filterArgs)))
 	}
 
 	if filter.ServiceName != nil && *filter.ServiceName != "" {
-		args = append(args, "%"+*filter.ServiceName+"%")
-		conditions = append(conditions, fmt.Sprintf("service_name ILIKE $%d", len(args)))
+		filterArgs = append(filterArgs, "%"+*filter.ServiceName+"%")
+		conditions = append(conditions, fmt.Sprintf("service_name ILIKE $%d", len(filterArgs)))
 	}
 
 	if filter.StartDate != nil && *filter.StartDate != "" {
-		args = append(args, *filter.StartDate)
-		conditions = append(conditions, fmt.Sprintf("start_date >= $%d", len(args)))
+		filterArgs = append(filterArgs, *filter.StartDate)
+		conditions = append(conditions, fmt.Sprintf("start_date >= $%d", len(filterArgs)))
 	}
 
 	if filter.EndDate != nil && *filter.EndDate != "" {
-		args = append(args, *filter.EndDate)
-		conditions = append(conditions, fmt.Sprintf("(end_date <= $%d OR end_date IS NULL)", len(args)))
+		filterArgs = append(filterArgs, *filter.EndDate)
+		conditions = append(conditions, fmt.Sprintf("(end_date <= $%d OR end_date IS NULL)", len(filterArgs)))
 	}
 
+	log.WithField("filter", filter).Info("Fetching all subscriptions")
+
+	var total int
+	countQuery := "SELECT COUNT(*) FROM subscriptions WHERE 1=1"
 	if len(conditions) > 0 {
-		query += " AND " + strings.Join(conditions, " AND ")
+		countQuery += " AND " + strings.Join(conditions, " AND ")
+	}
+	if err := r.db.QueryRowContext(ctx, countQuery, filterArgs...).Scan(&total); err != nil {
+		log.WithError(err).Error("Failed to count subscriptions")
+		endSpan(span, err)
+		return nil, err
 	}
 
-	query += " ORDER BY created_at DESC"
+	// The page query reuses the same filter args, then layers the cursor
+	// and limit/offset on top with their own placeholders.
+	args := append([]interface{}{}, filterArgs...)
+	pageConditions := append([]string{}, conditions...)
 
-	r.log.WithField("filter", filter).Info("Fetching all subscriptions")
+	var cur *cursor
+	if filter.Cursor != nil && *filter.Cursor != "" {
+		if !isDefaultSort(filter.SortBy, filter.SortOrder) {
+			endSpan(span, ErrCursorSortMismatch)
+			return nil, ErrCursorSortMismatch
+		}
+
+		var err error
+		cur, err = decodeCursor(*filter.Cursor)
+		if err != nil {
+			endSpan(span, err)
+			return nil, err
+		}
+		args = append(args, cur.CreatedAt, cur.ID)
+		pageConditions = append(pageConditions, fmt.Sprintf("(created_at, id) < ($%d, $%d)", len(args)-1, len(args)))
+	}
 
-	rows, err := r.db.Query(query, args...)
+	query := `
+        SELECT id, service_name, price, user_id, start_date, end_date, created_at, updated_at
+        FROM subscriptions
+        WHERE 1=1
+    `
+	if len(pageConditions) > 0 {
+		query += " AND " + strings.Join(pageConditions, " AND ")
+	}
+
+	sortBy, sortOrder := sortColumn(filter.SortBy), sortDirection(filter.SortOrder)
+	query += fmt.Sprintf(" ORDER BY %s %s, id %s", sortBy, sortOrder, sortOrder)
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	} else if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	// Fetch one extra row to know whether a next page exists, without a
+	// second round trip.
+	args = append(args, limit+1)
+	query += fmt.Sprintf(" LIMIT $%d", len(args))
+
+	if cur == nil && filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	span.SetAttributes(attribute.String("db.statement", query))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		r.log.WithError(err).Error("Failed to fetch subscriptions")
+		log.WithError(err).Error("Failed to fetch subscriptions")
+		endSpan(span, err)
 		return nil, err
 	}
 	defer rows.Close()
@@ -160,17 +331,72 @@ func (r *PostgresRepository) GetAll(filter *models.SubscriptionFilter) ([]*model
 			&sub.UpdatedAt,
 		)
 		if err != nil {
-			r.log.WithError(err).Error("Failed to scan subscription")
+			log.WithError(err).Error("Failed to scan subscription")
+			endSpan(span, err)
 			return nil, err
 		}
 		subscriptions = append(subscriptions, sub)
 	}
 
-	r.log.WithField("count", len(subscriptions)).Info("Subscriptions fetched successfully")
-	return subscriptions, nil
+	var nextCursor *string
+	if len(subscriptions) > limit {
+		last := subscriptions[limit-1]
+		c := encodeCursor(last.CreatedAt, last.ID)
+		nextCursor = &c
+		subscriptions = subscriptions[:limit]
+	}
+
+	log.WithField("count", len(subscriptions)).Info("Subscriptions fetched successfully")
+	span.SetAttributes(attribute.Int("db.rows_affected", len(subscriptions)))
+	endSpan(span, nil)
+	return &models.SubscriptionListResponse{
+		Data:       subscriptions,
+		NextCursor: nextCursor,
+		Total:      total,
+	}, nil
 }
 
-func (r *PostgresRepository) Update(id uuid.UUID, req *models.UpdateSubscriptionRequest) error {
+func sortColumn(sortBy string) string {
+	switch sortBy {
+	case "price":
+		return "price"
+	case "service_name":
+		return "service_name"
+	case "start_date":
+		return "start_date"
+	default:
+		return "created_at"
+	}
+}
+
+func sortDirection(sortOrder string) string {
+	if strings.EqualFold(sortOrder, "asc") {
+		return "ASC"
+	}
+	return "DESC"
+}
+
+// endDatePassed reports whether sub's end_date (MM-YYYY) has fully elapsed
+// as of now, i.e. now is on or after the first day of the following month.
+func endDatePassed(endDate *string, now time.Time) bool {
+	if endDate == nil {
+		return false
+	}
+	end, err := time.Parse("01-2006", *endDate)
+	if err != nil {
+		return false
+	}
+	return !now.Before(end.AddDate(0, 1, 0))
+}
+
+func (r *PostgresRepository) Update(ctx context.Context, id uuid.UUID, req *models.UpdateSubscriptionRequest) error {
+	ctx, span := startSpan(ctx, "update", "")
+	defer span.End()
+
+	log := logger.FromContext(ctx)
+
+	existing, _ := r.GetByID(ctx, id)
+
 	query := "UPDATE subscriptions SET updated_at = $1"
 	args := []interface{}{time.Now()}
 	updates := []string{}
@@ -191,51 +417,192 @@ func (r *PostgresRepository) Update(id uuid.UUID, req *models.UpdateSubscription
 	}
 
 	if len(updates) == 0 {
+		endSpan(span, nil)
 		return nil
 	}
 
 	query += ", " + strings.Join(updates, ", ")
 	args = append(args, id)
 	query += fmt.Sprintf(" WHERE id = $%d", len(args))
+	span.SetAttributes(attribute.String("db.statement", query))
 
-	r.log.WithField("id", id).Info("Updating subscription")
+	log.WithField("id", id).Info("Updating subscription")
 
-	result, err := r.db.Exec(query, args...)
+	result, err := r.db.ExecContext(ctx, query, args...)
 	if err != nil {
-		r.log.WithError(err).WithField("id", id).Error("Failed to update subscription")
+		log.WithError(err).WithField("id", id).Error("Failed to update subscription")
+		endSpan(span, err)
 		return err
 	}
 
 	rowsAffected, _ := result.RowsAffected()
+	span.SetAttributes(attribute.Int64("db.rows_affected", rowsAffected))
 	if rowsAffected == 0 {
-		r.log.WithField("id", id).Warn("Subscription not found for update")
+		log.WithField("id", id).Warn("Subscription not found for update")
+		endSpan(span, sql.ErrNoRows)
 		return sql.ErrNoRows
 	}
 
+	if updated, err := r.GetByID(ctx, id); err == nil && updated != nil {
+		eventType := notifier.EventSubscriptionUpdated
+		if req.EndDate != nil && existing != nil {
+			now := time.Now()
+			wasExpired := endDatePassed(existing.EndDate, now)
+			isExpired := endDatePassed(updated.EndDate, now)
+			switch {
+			case isExpired && !wasExpired:
+				eventType = notifier.EventSubscriptionExpired
+			case wasExpired && !isExpired:
+				eventType = notifier.EventSubscriptionRenewed
+			}
+		}
+		r.publish(ctx, eventType, updated)
+
+		// publish has no CloudEvents change type for expired/renewed, but
+		// the row was still updated, so the change stream gets "updated".
+		if eventType != notifier.EventSubscriptionUpdated && r.publisher != nil {
+			ev, err := events.NewSubscriptionEvent(events.ChangeUpdated, updated)
+			if err != nil {
+				log.WithError(err).Error("Failed to build CloudEvent for subscription change")
+			} else {
+				r.publisher.Publish(ctx, ev, updated)
+			}
+		}
+	}
+
+	endSpan(span, nil)
 	return nil
 }
 
-func (r *PostgresRepository) Delete(id uuid.UUID) error {
+func (r *PostgresRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := startSpan(ctx, "delete", "")
+	defer span.End()
+
+	log := logger.FromContext(ctx)
+
+	deleted, _ := r.GetByID(ctx, id)
+
 	query := "DELETE FROM subscriptions WHERE id = $1"
+	span.SetAttributes(attribute.String("db.statement", query))
 
-	r.log.WithField("id", id).Info("Deleting subscription")
+	log.WithField("id", id).Info("Deleting subscription")
 
-	result, err := r.db.Exec(query, id)
+	result, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
-		r.log.WithError(err).WithField("id", id).Error("Failed to delete subscription")
+		log.WithError(err).WithField("id", id).Error("Failed to delete subscription")
+		endSpan(span, err)
 		return err
 	}
 
 	rowsAffected, _ := result.RowsAffected()
+	span.SetAttributes(attribute.Int64("db.rows_affected", rowsAffected))
 	if rowsAffected == 0 {
-		r.log.WithField("id", id).Warn("Subscription not found for deletion")
+		log.WithField("id", id).Warn("Subscription not found for deletion")
+		endSpan(span, sql.ErrNoRows)
 		return sql.ErrNoRows
 	}
 
+	if deleted != nil {
+		r.publish(ctx, notifier.EventSubscriptionDeleted, deleted)
+	}
+
+	endSpan(span, nil)
+	return nil
+}
+
+// RunExpirySweep periodically calls SweepExpired until ctx is cancelled. It
+// is meant to be started once as a background goroutine, the same way
+// dispatcher.Run and fanout.Run are: without it, subscription.expired is
+// only ever published as a side effect of a PATCH that happens to touch
+// end_date, so a subscription whose period quietly elapses with nobody
+// touching it would never get the event.
+func (r *PostgresRepository) RunExpirySweep(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.SweepExpired(ctx); err != nil {
+				logger.FromContext(ctx).WithError(err).Error("Expiry sweep failed")
+			}
+		}
+	}
+}
+
+// SweepExpired publishes subscription.expired for every subscription whose
+// end_date newly crossed now since the previous sweep. It is safe to call
+// concurrently, but is meant to be driven serially by RunExpirySweep.
+func (r *PostgresRepository) SweepExpired(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "sweep_expired", "")
+	defer span.End()
+
+	log := logger.FromContext(ctx)
+
+	r.sweepMu.Lock()
+	baseline := r.sweptAt
+	now := time.Now()
+	r.sweptAt = now
+	r.sweepMu.Unlock()
+
+	candidates, err := r.listWithEndDate(ctx)
+	if err != nil {
+		log.WithError(err).Error("Failed to list subscriptions for expiry sweep")
+		endSpan(span, err)
+		return err
+	}
+
+	expired := 0
+	for _, sub := range candidates {
+		if endDatePassed(sub.EndDate, now) && !endDatePassed(sub.EndDate, baseline) {
+			r.publish(ctx, notifier.EventSubscriptionExpired, sub)
+			expired++
+		}
+	}
+
+	log.WithField("expired", expired).Info("Expiry sweep completed")
+	span.SetAttributes(attribute.Int("db.rows_affected", len(candidates)))
+	endSpan(span, nil)
 	return nil
 }
 
-func (r *PostgresRepository) GetTotalCost(filter *models.SubscriptionFilter, startDate, endDate string) (int, int, error) {
+// listWithEndDate returns every subscription with a non-null end_date, for
+// SweepExpired to check against the elapsed-baseline window.
+func (r *PostgresRepository) listWithEndDate(ctx context.Context) ([]*models.Subscription, error) {
+	query := `
+        SELECT id, service_name, price, user_id, start_date, end_date, created_at, updated_at
+        FROM subscriptions
+        WHERE end_date IS NOT NULL
+    `
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subscriptions []*models.Subscription
+	for rows.Next() {
+		sub := &models.Subscription{}
+		if err := rows.Scan(
+			&sub.ID,
+			&sub.ServiceName,
+			&sub.Price,
+			&sub.UserID,
+			&sub.StartDate,
+			&sub.EndDate,
+			&sub.CreatedAt,
+			&sub.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, sub)
+	}
+	return subscriptions, rows.Err()
+}
+
+func (r *PostgresRepository) GetTotalCost(ctx context.Context, filter *models.SubscriptionFilter, startDate, endDate string) (int, int, error) {
 	query := `
         SELECT COALESCE(SUM(price), 0), COUNT(*)
         FROM subscriptions
@@ -258,23 +625,172 @@ func (r *PostgresRepository) GetTotalCost(filter *models.SubscriptionFilter, sta
 		query += " AND " + strings.Join(conditions, " AND ")
 	}
 
-	r.log.WithFields(logrus.Fields{
+	ctx, span := startSpan(ctx, "get_total_cost", query)
+	defer span.End()
+
+	log := logger.FromContext(ctx)
+	log.WithFields(logrus.Fields{
 		"start_date": startDate,
 		"end_date":   endDate,
 		"filter":     filter,
 	}).Info("Calculating total cost")
 
 	var totalCost, count int
-	err := r.db.QueryRow(query, args...).Scan(&totalCost, &count)
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&totalCost, &count)
 	if err != nil {
-		r.log.WithError(err).Error("Failed to calculate total cost")
+		log.WithError(err).Error("Failed to calculate total cost")
+		endSpan(span, err)
 		return 0, 0, err
 	}
 
-	r.log.WithFields(logrus.Fields{
+	log.WithFields(logrus.Fields{
 		"total_cost": totalCost,
 		"count":      count,
 	}).Info("Total cost calculated successfully")
 
+	endSpan(span, nil)
 	return totalCost, count, nil
 }
+
+// Query answers POST /subscriptions:query, whose filters (price ranges,
+// active-at-date, IN lists) don't fit cleanly into query parameters.
+func (r *PostgresRepository) Query(ctx context.Context, req *models.SubscriptionQueryRequest) (*models.SubscriptionListResponse, error) {
+	ctx, span := startSpan(ctx, "query", "")
+	defer span.End()
+
+	log := logger.FromContext(ctx)
+
+	conditions := []string{}
+	args := []interface{}{}
+
+	if req.PriceMin != nil {
+		args = append(args, *req.PriceMin)
+		conditions = append(conditions, fmt.Sprintf("price >= $%d", len(args)))
+	}
+
+	if req.PriceMax != nil {
+		args = append(args, *req.PriceMax)
+		conditions = append(conditions, fmt.Sprintf("price <= $%d", len(args)))
+	}
+
+	if req.ActiveAt != nil && *req.ActiveAt != "" {
+		args = append(args, *req.ActiveAt, *req.ActiveAt)
+		conditions = append(conditions, fmt.Sprintf("start_date <= $%d AND (end_date IS NULL OR end_date >= $%d)", len(args)-1, len(args)))
+	}
+
+	if len(req.ServiceNames) > 0 {
+		args = append(args, pq.Array(req.ServiceNames))
+		conditions = append(conditions, fmt.Sprintf("service_name = ANY($%d)", len(args)))
+	}
+
+	if len(req.UserIDs) > 0 {
+		args = append(args, pq.Array(req.UserIDs))
+		conditions = append(conditions, fmt.Sprintf("user_id = ANY($%d)", len(args)))
+	}
+
+	log.WithField("query", req).Info("Querying subscriptions")
+
+	var total int
+	countQuery := "SELECT COUNT(*) FROM subscriptions WHERE 1=1"
+	if len(conditions) > 0 {
+		countQuery += " AND " + strings.Join(conditions, " AND ")
+	}
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		log.WithError(err).Error("Failed to count queried subscriptions")
+		endSpan(span, err)
+		return nil, err
+	}
+
+	pageArgs := append([]interface{}{}, args...)
+	pageConditions := append([]string{}, conditions...)
+
+	var cur *cursor
+	if req.Cursor != nil && *req.Cursor != "" {
+		if !isDefaultSort(req.SortBy, req.SortOrder) {
+			endSpan(span, ErrCursorSortMismatch)
+			return nil, ErrCursorSortMismatch
+		}
+
+		var err error
+		cur, err = decodeCursor(*req.Cursor)
+		if err != nil {
+			endSpan(span, err)
+			return nil, err
+		}
+		pageArgs = append(pageArgs, cur.CreatedAt, cur.ID)
+		pageConditions = append(pageConditions, fmt.Sprintf("(created_at, id) < ($%d, $%d)", len(pageArgs)-1, len(pageArgs)))
+	}
+
+	query := `
+        SELECT id, service_name, price, user_id, start_date, end_date, created_at, updated_at
+        FROM subscriptions
+        WHERE 1=1
+    `
+	if len(pageConditions) > 0 {
+		query += " AND " + strings.Join(pageConditions, " AND ")
+	}
+
+	sortBy, sortOrder := sortColumn(req.SortBy), sortDirection(req.SortOrder)
+	query += fmt.Sprintf(" ORDER BY %s %s, id %s", sortBy, sortOrder, sortOrder)
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	} else if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	pageArgs = append(pageArgs, limit+1)
+	query += fmt.Sprintf(" LIMIT $%d", len(pageArgs))
+
+	if cur == nil && req.Offset > 0 {
+		pageArgs = append(pageArgs, req.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(pageArgs))
+	}
+
+	span.SetAttributes(attribute.String("db.statement", query))
+
+	rows, err := r.db.QueryContext(ctx, query, pageArgs...)
+	if err != nil {
+		log.WithError(err).Error("Failed to query subscriptions")
+		endSpan(span, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subscriptions []*models.Subscription
+	for rows.Next() {
+		sub := &models.Subscription{}
+		if err := rows.Scan(
+			&sub.ID,
+			&sub.ServiceName,
+			&sub.Price,
+			&sub.UserID,
+			&sub.StartDate,
+			&sub.EndDate,
+			&sub.CreatedAt,
+			&sub.UpdatedAt,
+		); err != nil {
+			log.WithError(err).Error("Failed to scan subscription")
+			endSpan(span, err)
+			return nil, err
+		}
+		subscriptions = append(subscriptions, sub)
+	}
+
+	var nextCursor *string
+	if len(subscriptions) > limit {
+		last := subscriptions[limit-1]
+		c := encodeCursor(last.CreatedAt, last.ID)
+		nextCursor = &c
+		subscriptions = subscriptions[:limit]
+	}
+
+	span.SetAttributes(attribute.Int("db.rows_affected", len(subscriptions)))
+	endSpan(span, nil)
+	return &models.SubscriptionListResponse{
+		Data:       subscriptions,
+		NextCursor: nextCursor,
+		Total:      total,
+	}, nil
+}